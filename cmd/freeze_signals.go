@@ -0,0 +1,24 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rancher/rancher-manager-mcp/internal/server"
+)
+
+// handleFreezeSignals hides the write tools on SIGUSR1, for example during a
+// change freeze, and brings them back on SIGUSR2, without restarting or
+// reconnecting clients
+func handleFreezeSignals(srv *server.Server) {
+	freezeChan := make(chan os.Signal, 1)
+	signal.Notify(freezeChan, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for sig := range freezeChan {
+			srv.SetWriteToolsEnabled(sig == syscall.SIGUSR2)
+		}
+	}()
+}
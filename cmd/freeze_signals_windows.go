@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "github.com/rancher/rancher-manager-mcp/internal/server"
+
+// handleFreezeSignals does nothing: Windows has no SIGUSR1 or SIGUSR2
+func handleFreezeSignals(srv *server.Server) {}
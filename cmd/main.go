@@ -4,12 +4,16 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
+	"github.com/rancher/rancher-manager-mcp/internal/auth"
+	"github.com/rancher/rancher-manager-mcp/internal/client"
 	"github.com/rancher/rancher-manager-mcp/internal/server"
 	"github.com/sirupsen/logrus"
 )
@@ -22,6 +26,19 @@ func main() {
 		rancherToken       = flag.String("rancher-token", "", "Rancher API token")
 		insecureSkipVerify = flag.Bool("insecure-skip-verify", false, "Skip SSL certificate verification (not recommended)")
 		logLevel           = flag.String("log-level", "info", "Log level: debug, info, warn, error")
+		tokenPassthrough   = flag.Bool("token-passthrough", false, "HTTP transport: act with each caller's Rancher token from the Authorization header")
+		apiKeysFile        = flag.String("api-keys-file", "", "HTTP transport: JSON file of API keys accepted as bearer tokens")
+		oidcIssuer         = flag.String("oidc-issuer", "", "HTTP transport: accept JWTs from this OIDC issuer")
+		oidcAudience       = flag.String("oidc-audience", "", "Required audience of OIDC tokens")
+		oidcJWKSURL        = flag.String("oidc-jwks-url", "", "JWKS URL of the OIDC issuer (discovered if empty)")
+		oidcUsernameClaim  = flag.String("oidc-username-claim", "sub", "OIDC claim used as the caller's name")
+		oidcGroupsClaim    = flag.String("oidc-groups-claim", "groups", "OIDC claim holding the caller's groups")
+		toolPolicyFile     = flag.String("tool-policy-file", "", "JSON policy deciding which tools each authenticated caller may use")
+		readOnly           = flag.Bool("read-only", false, "Register only list, get and status tools")
+		enableTools        = flag.String("enable-tools", "", "Comma-separated tool name globs to register, such as 'list_*,get_*' (default all)")
+		disableTools       = flag.String("disable-tools", "", "Comma-separated tool name globs not to register, such as 'delete_*,*_token*'")
+		maxRetries         = flag.Int("rancher-max-retries", client.DefaultRetryPolicy.MaxRetries, "Times a failed Rancher request that is safe to repeat is retried (0 disables retries)")
+		toolsPageSize      = flag.Int("tools-page-size", 100, "Maximum number of tools per tools/list page (0 disables pagination)")
 	)
 	flag.Parse()
 
@@ -32,6 +49,43 @@ func main() {
 	if *rancherToken == "" {
 		*rancherToken = os.Getenv("RANCHER_TOKEN")
 	}
+	for flagValue, env := range map[*string]string{
+		apiKeysFile:    "MCP_API_KEYS_FILE",
+		oidcIssuer:     "MCP_OIDC_ISSUER",
+		oidcAudience:   "MCP_OIDC_AUDIENCE",
+		oidcJWKSURL:    "MCP_OIDC_JWKS_URL",
+		toolPolicyFile: "MCP_TOOL_POLICY_FILE",
+		enableTools:    "MCP_ENABLE_TOOLS",
+		disableTools:   "MCP_DISABLE_TOOLS",
+	} {
+		if *flagValue == "" {
+			*flagValue = os.Getenv(env)
+		}
+	}
+	if !*readOnly {
+		if os.Getenv("MCP_READ_ONLY") == "true" || os.Getenv("MCP_READ_ONLY") == "1" {
+			*readOnly = true
+		}
+	}
+	if !*tokenPassthrough {
+		if os.Getenv("RANCHER_TOKEN_PASSTHROUGH") == "true" || os.Getenv("RANCHER_TOKEN_PASSTHROUGH") == "1" {
+			*tokenPassthrough = true
+		}
+	}
+	maxRetriesSet := false
+	flag.Visit(func(f *flag.Flag) {
+		maxRetriesSet = maxRetriesSet || f.Name == "rancher-max-retries"
+	})
+	if env := os.Getenv("RANCHER_MAX_RETRIES"); env != "" && !maxRetriesSet {
+		n, err := strconv.Atoi(env)
+		if err != nil {
+			log.Fatalf("Invalid RANCHER_MAX_RETRIES: %v", err)
+		}
+		*maxRetries = n
+	}
+	if *maxRetries < 0 {
+		log.Fatalf("--rancher-max-retries must not be negative")
+	}
 	// Check environment variable for SSL verification (flag takes precedence)
 	if !*insecureSkipVerify {
 		if os.Getenv("RANCHER_INSECURE_SKIP_VERIFY") == "true" || os.Getenv("RANCHER_INSECURE_SKIP_VERIFY") == "1" {
@@ -46,8 +100,86 @@ func main() {
 	}
 	logrus.SetLevel(level)
 
+	// In stdio mode stderr would interfere with the MCP protocol, so log
+	// entries only reach the client as notifications/message. Every level is
+	// produced and each client picks its own threshold with logging/setLevel.
+	if *transport == "stdio" {
+		logrus.SetOutput(io.Discard)
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+
+	// Set up authentication for the HTTP transport
+	var authenticators []auth.Authenticator
+	if *apiKeysFile != "" {
+		apiKeys, err := auth.LoadAPIKeys(*apiKeysFile)
+		if err != nil {
+			log.Fatalf("Failed to load API keys: %v", err)
+		}
+		authenticators = append(authenticators, apiKeys)
+	}
+	if *oidcIssuer != "" {
+		if *oidcAudience == "" {
+			log.Fatalf("--oidc-issuer requires --oidc-audience")
+		}
+		oidc, err := auth.NewOIDCAuthenticator(auth.OIDCConfig{
+			Issuer:        *oidcIssuer,
+			Audience:      *oidcAudience,
+			JWKSURL:       *oidcJWKSURL,
+			UsernameClaim: *oidcUsernameClaim,
+			GroupsClaim:   *oidcGroupsClaim,
+		})
+		if err != nil {
+			log.Fatalf("Failed to configure OIDC: %v", err)
+		}
+		authenticators = append(authenticators, oidc)
+	}
+	var authenticator auth.Authenticator
+	if len(authenticators) > 0 {
+		authenticator = auth.Chain(authenticators...)
+	}
+
+	var policy *auth.Policy
+	if *toolPolicyFile != "" {
+		if authenticator == nil {
+			log.Fatalf("--tool-policy-file requires --api-keys-file or --oidc-issuer")
+		}
+		policy, err = auth.LoadPolicy(*toolPolicyFile)
+		if err != nil {
+			log.Fatalf("Failed to load tool policy: %v", err)
+		}
+	}
+
+	enabledTools, err := server.ParseToolPatterns(*enableTools)
+	if err != nil {
+		log.Fatalf("Invalid --enable-tools: %v", err)
+	}
+	disabledTools, err := server.ParseToolPatterns(*disableTools)
+	if err != nil {
+		log.Fatalf("Invalid --disable-tools: %v", err)
+	}
+
+	retryPolicy := client.DefaultRetryPolicy
+	retryPolicy.MaxRetries = *maxRetries
+
 	// Create server
-	srv := server.NewServer(*rancherURL, *rancherToken, *insecureSkipVerify)
+	srv := server.NewServerWithOptions(server.Options{
+		RancherURL:         *rancherURL,
+		RancherToken:       *rancherToken,
+		InsecureSkipVerify: *insecureSkipVerify,
+		TokenPassthrough:   *tokenPassthrough,
+		RetryPolicy:        &retryPolicy,
+		Authenticator:      authenticator,
+		Policy:             policy,
+		Tools: server.ToolFilter{
+			ReadOnly: *readOnly,
+			Enable:   enabledTools,
+			Disable:  disabledTools,
+		},
+	})
+	srv.SetToolsPageSize(*toolsPageSize)
+	if err := srv.EnableClientLogging(logrus.StandardLogger(), server.MCPLoggingLevel(level)); err != nil {
+		log.Fatalf("Failed to enable client logging: %v", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -56,6 +188,8 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	handleFreezeSignals(srv)
+
 	switch *transport {
 	case "stdio":
 		// Don't log to stderr in stdio mode - it interferes with MCP protocol
@@ -67,11 +201,15 @@ func main() {
 		}
 	case "http":
 		logrus.Infof("Starting MCP server with HTTP transport on %s", *httpAddr)
+		if authenticator == nil {
+			logrus.Warn("HTTP transport has no authentication configured; anyone who can reach it can use every tool")
+		}
 		httpServer := &http.Server{
 			Addr:    *httpAddr,
 			Handler: srv.HTTPHandler(),
 		}
 
+		go srv.ReapIdleSessions(ctx)
 		go func() {
 			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 				logrus.Fatalf("HTTP server error: %v", err)
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// apiKeyFile is the format of the API key file:
+//
+//	{"keys": [{"name": "ci-bot", "key": "sha256:<hex>", "groups": ["readers"]}]}
+//
+// A key is either the literal secret or "sha256:" followed by the hex SHA-256
+// digest of it, so that the file need not contain the secret itself.
+type apiKeyFile struct {
+	Keys []struct {
+		Name   string   `json:"name"`
+		Key    string   `json:"key"`
+		Groups []string `json:"groups"`
+	} `json:"keys"`
+}
+
+// APIKeyAuthenticator accepts static bearer keys
+type APIKeyAuthenticator struct {
+	// identities is keyed by the hex SHA-256 digest of each key
+	identities map[string]*Identity
+}
+
+// LoadAPIKeys reads an API key file
+func LoadAPIKeys(path string) (*APIKeyAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key file: %w", err)
+	}
+
+	var file apiKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse API key file %s: %w", path, err)
+	}
+
+	a := &APIKeyAuthenticator{identities: make(map[string]*Identity, len(file.Keys))}
+	for i, k := range file.Keys {
+		if k.Name == "" || k.Key == "" {
+			return nil, fmt.Errorf("API key file %s: entry %d needs a name and a key", path, i)
+		}
+		digest, hashed := strings.CutPrefix(k.Key, "sha256:")
+		if hashed {
+			digest = strings.ToLower(digest)
+			if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("API key file %s: key %q is not a valid SHA-256 digest", path, k.Name)
+			}
+		} else {
+			digest = hashAPIKey(k.Key)
+		}
+		if _, exists := a.identities[digest]; exists {
+			return nil, fmt.Errorf("API key file %s: key %q is listed twice", path, k.Name)
+		}
+		a.identities[digest] = &Identity{
+			Subject: k.Name,
+			Groups:  k.Groups,
+			Method:  "apikey",
+		}
+	}
+	return a, nil
+}
+
+// Authenticate accepts a request whose bearer token is a known key. Unknown
+// tokens are left to other authenticators, since they may be JWTs.
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := BearerToken(r)
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	id, ok := a.identities[hashAPIKey(token)]
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	return id, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return file
+}
+
+func TestAPIKeys(t *testing.T) {
+	file := writeFile(t, "keys.json", `{"keys": [
+		{"name": "ci-bot", "key": "plain-secret", "groups": ["readers"]},
+		{"name": "admin", "key": "sha256:`+hashAPIKey("hashed-secret")+`"}
+	]}`)
+	a, err := LoadAPIKeys(file)
+	if err != nil {
+		t.Fatalf("LoadAPIKeys failed: %v", err)
+	}
+
+	id, err := a.Authenticate(requestWithToken("plain-secret"))
+	if err != nil || id.Subject != "ci-bot" || id.Method != "apikey" || len(id.Groups) != 1 {
+		t.Errorf("plain key: got %+v, %v", id, err)
+	}
+	id, err = a.Authenticate(requestWithToken("hashed-secret"))
+	if err != nil || id.Subject != "admin" {
+		t.Errorf("hashed key: got %+v, %v", id, err)
+	}
+	if _, err := a.Authenticate(requestWithToken("unknown")); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("unknown key: expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestLoadAPIKeysRejectsInvalidFiles(t *testing.T) {
+	for name, content := range map[string]string{
+		"malformed":  `{"keys": [`,
+		"no name":    `{"keys": [{"key": "secret"}]}`,
+		"bad digest": `{"keys": [{"name": "a", "key": "sha256:abc"}]}`,
+		"duplicate":  `{"keys": [{"name": "a", "key": "secret"}, {"name": "b", "key": "secret"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadAPIKeys(writeFile(t, "keys.json", content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	file := writeFile(t, "keys.json", `{"keys": [{"name": "ci-bot", "key": "secret"}]}`)
+	keys, err := LoadAPIKeys(file)
+	if err != nil {
+		t.Fatalf("LoadAPIKeys failed: %v", err)
+	}
+
+	var seen *Identity
+	handler := Middleware(Chain(keys), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"no credentials", "", http.StatusUnauthorized},
+		{"unknown key", "wrong", http.StatusUnauthorized},
+		{"valid key", "secret", http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = nil
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Fatalf("status = %d, want %d", w.Code, tc.status)
+			}
+			if tc.status == http.StatusUnauthorized {
+				if w.Header().Get("WWW-Authenticate") == "" {
+					t.Error("missing WWW-Authenticate header")
+				}
+				if seen != nil {
+					t.Error("handler ran for a rejected request")
+				}
+			} else if seen == nil || seen.Subject != "ci-bot" {
+				t.Errorf("identity in context = %+v", seen)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package auth authenticates callers of the HTTP MCP endpoint and decides
+// which tools each of them may use.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Identity is an authenticated caller
+type Identity struct {
+	// Subject is the API key name or the token's subject claim
+	Subject string
+	Groups  []string
+	// Method names the authenticator that accepted the caller, such as
+	// "apikey" or "oidc"
+	Method string
+}
+
+// Qualified prefixes name, the identity's subject or one of its groups, with
+// the method that vouched for it, as in "oidc:alice". Names from different
+// methods never compare equal, so an OIDC user whose subject matches an API
+// key's name is still a different caller.
+func (id *Identity) Qualified(name string) string {
+	return id.Method + ":" + name
+}
+
+// Authenticator validates the credentials of a request
+type Authenticator interface {
+	// Authenticate returns the caller's identity. It returns ErrNoCredentials
+	// when the request carries nothing this authenticator understands.
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// ErrNoCredentials means a request carries no credentials an authenticator
+// can handle
+var ErrNoCredentials = errors.New("no credentials")
+
+// ErrInvalidCredentials means a request carries credentials that were rejected
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type identityContextKey struct{}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, id)
+}
+
+// FromContext returns the identity attached to ctx, if any
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityContextKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// BearerToken extracts the token from an "Authorization: Bearer" header
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// chain tries several authenticators in order
+type chain []Authenticator
+
+// Chain returns an Authenticator that accepts a request if any of
+// authenticators does. The first one that recognizes the credentials decides.
+func Chain(authenticators ...Authenticator) Authenticator {
+	if len(authenticators) == 1 {
+		return authenticators[0]
+	}
+	return chain(authenticators)
+}
+
+func (c chain) Authenticate(r *http.Request) (*Identity, error) {
+	for _, a := range c {
+		id, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+	return nil, ErrNoCredentials
+}
+
+// Middleware rejects requests that authn does not accept and attaches the
+// caller's identity to the context of those it does
+func Middleware(authn Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := authn.Authenticate(r)
+		if err != nil {
+			logrus.WithContext(r.Context()).Debugf("Authentication failed from %s: %v", r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="rancher-mcp"`)
+			if errors.Is(err, ErrNoCredentials) && BearerToken(r) == "" {
+				http.Error(w, "Authentication required", http.StatusUnauthorized)
+			} else {
+				http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+			}
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
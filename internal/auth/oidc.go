@@ -0,0 +1,440 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksCacheTTL is how long fetched signing keys are trusted before the
+	// key set is fetched again
+	jwksCacheTTL = time.Hour
+	// jwksMinRefreshInterval limits refetches, so that tokens signed with
+	// unknown key IDs or an unreachable issuer cannot hammer the issuer
+	jwksMinRefreshInterval = time.Minute
+	// clockSkew is the leeway allowed when checking exp, nbf and iat
+	clockSkew = time.Minute
+)
+
+// OIDCConfig configures JWT validation
+type OIDCConfig struct {
+	// Issuer must match the iss claim exactly
+	Issuer string
+	// Audience must appear in the aud claim. It is required, so that tokens
+	// the issuer signed for other clients are not accepted here.
+	Audience string
+	// JWKSURL is where signing keys are fetched. If empty it is discovered
+	// from the issuer's /.well-known/openid-configuration.
+	JWKSURL string
+	// UsernameClaim names the claim used as the identity's subject
+	// (default "sub")
+	UsernameClaim string
+	// GroupsClaim names the claim holding the caller's groups
+	// (default "groups")
+	GroupsClaim string
+	// HTTPClient fetches discovery documents and key sets
+	HTTPClient *http.Client
+}
+
+// OIDCAuthenticator validates bearer JWTs signed by an OIDC issuer
+type OIDCAuthenticator struct {
+	config OIDCConfig
+
+	mu        sync.Mutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	// lastAttempt rate-limits refreshes, successful or not
+	lastAttempt time.Time
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewOIDCAuthenticator returns an authenticator for config. Keys are fetched
+// lazily on the first request.
+func NewOIDCAuthenticator(config OIDCConfig) (*OIDCAuthenticator, error) {
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("OIDC issuer is required")
+	}
+	if config.Audience == "" {
+		return nil, fmt.Errorf("OIDC audience is required")
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "sub"
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OIDCAuthenticator{
+		config:  config,
+		jwksURL: config.JWKSURL,
+		now:     time.Now,
+	}, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Authenticate accepts a request whose bearer token is a valid JWT
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := BearerToken(r)
+	if token == "" || strings.Count(token, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verify(r.Context(), token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	subject, _ := claims[a.config.UsernameClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token has no %s claim", ErrInvalidCredentials, a.config.UsernameClaim)
+	}
+	return &Identity{
+		Subject: subject,
+		Groups:  stringList(claims[a.config.GroupsClaim]),
+		Method:  "oidc",
+	}, nil
+}
+
+// verify checks the signature and standard claims of token and returns its claims
+func (a *OIDCAuthenticator) verify(ctx context.Context, token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+
+	key, err := a.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed claims: %w", err)
+	}
+	if err := a.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (a *OIDCAuthenticator) validateClaims(claims map[string]interface{}) error {
+	if iss, _ := claims["iss"].(string); iss != a.config.Issuer {
+		return fmt.Errorf("unexpected issuer %q", iss)
+	}
+	found := false
+	for _, aud := range stringList(claims["aud"]) {
+		if aud == a.config.Audience {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("token is not intended for audience %q", a.config.Audience)
+	}
+
+	now := a.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("token has no expiry")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return fmt.Errorf("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("token not valid yet")
+	}
+	if iat, ok := claims["iat"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(iat), 0)) {
+		return fmt.Errorf("token issued in the future")
+	}
+	return nil
+}
+
+// signingKey returns the key with ID kid, refreshing the cached key set when
+// it is stale or does not contain kid. The key set is fetched without holding
+// a.mu, so other requests keep using the cached keys meanwhile.
+func (a *OIDCAuthenticator) signingKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	now := a.now()
+	key, found := a.lookupKey(kid)
+	stale := a.keys == nil || now.Sub(a.fetchedAt) > jwksCacheTTL
+	refresh := (stale || !found) && now.Sub(a.lastAttempt) >= jwksMinRefreshInterval
+	if refresh {
+		a.lastAttempt = now
+	}
+	jwksURL := a.jwksURL
+	a.mu.Unlock()
+
+	if refresh {
+		keys, jwksURL, err := a.fetchKeys(ctx, jwksURL)
+		if err != nil {
+			// Keep serving from a stale key set while the issuer is unreachable
+			if !found {
+				return nil, err
+			}
+			return key, nil
+		}
+		a.mu.Lock()
+		a.jwksURL = jwksURL
+		a.keys = keys
+		a.fetchedAt = a.now()
+		key, found = a.lookupKey(kid)
+		a.mu.Unlock()
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// lookupKey finds a key by ID. A token without a kid is accepted only when
+// the key set holds a single key. It is called with a.mu held.
+func (a *OIDCAuthenticator) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key, true
+		}
+	}
+	key, ok := a.keys[kid]
+	return key, ok
+}
+
+// fetchKeys fetches the key set from jwksURL, discovering the URL first when
+// it is empty. It returns the keys and the URL they came from.
+func (a *OIDCAuthenticator) fetchKeys(ctx context.Context, jwksURL string) (map[string]crypto.PublicKey, string, error) {
+	if jwksURL == "" {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(a.config.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := a.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return nil, "", fmt.Errorf("OIDC discovery failed: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, "", fmt.Errorf("OIDC discovery document has no jwks_uri")
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := a.getJSON(ctx, jwksURL, &set); err != nil {
+		return nil, "", fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip key types we do not support rather than rejecting the set
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, jwksURL, nil
+}
+
+func (a *OIDCAuthenticator) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := a.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// jsonWebKey is a public key from a JWKS document
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// ecdsaCurves is the curve each ECDSA algorithm signs with
+var ecdsaCurves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+}
+
+// verifySignature checks sig over signed with key, whose type and curve must
+// suit alg. Only asymmetric algorithms are accepted, so "none" and HMAC
+// tokens fail.
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	digest := hashBytes(hash, []byte(signed))
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, hash, digest, sig); err != nil {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if curve := ecdsaCurves[alg]; curve == nil || curve != pub.Curve {
+			return fmt.Errorf("algorithm %s does not match EC key on curve %s", alg, pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+func hashBytes(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384(data)
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512(data)
+		return sum[:]
+	default:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	}
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// stringList reads a claim that may be a single string or a list of strings
+func stringList(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		out := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,349 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksFixture serves a discovery document and a JWKS with one RSA and one EC
+// signing key, and counts how often the key set is fetched
+type jwksFixture struct {
+	server  *httptest.Server
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	fetches atomic.Int32
+}
+
+func newJWKSFixture(t *testing.T) *jwksFixture {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	f := &jwksFixture{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   f.server.URL,
+			"jwks_uri": f.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		f.fetches.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa-1",
+					"use": "sig",
+					"n":   b64(rsaKey.N.Bytes()),
+					"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec-1",
+					"crv": "P-256",
+					"x":   b64(ecKey.X.FillBytes(make([]byte, 32))),
+					"y":   b64(ecKey.Y.FillBytes(make([]byte, 32))),
+				},
+			},
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// sign builds a JWT with the given header fields and claims
+func (f *jwksFixture) sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+
+	var sig []byte
+	switch alg {
+	case "RS256":
+		digest := hashBytes(crypto.SHA256, []byte(signed))
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, f.rsaKey, crypto.SHA256, digest)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+	case "ES256":
+		digest := hashBytes(crypto.SHA256, []byte(signed))
+		r, s, err := ecdsa.Sign(rand.Reader, f.ecKey, digest)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case "none":
+	default:
+		t.Fatalf("unsupported test algorithm %s", alg)
+	}
+	return signed + "." + b64(sig)
+}
+
+func (f *jwksFixture) claims(overrides map[string]interface{}) map[string]interface{} {
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":    f.server.URL,
+		"sub":    "alice",
+		"aud":    []string{"rancher-mcp", "other"},
+		"exp":    now.Add(time.Hour).Unix(),
+		"iat":    now.Unix(),
+		"groups": []string{"operators", "readers"},
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(claims, k)
+		} else {
+			claims[k] = v
+		}
+	}
+	return claims
+}
+
+func requestWithToken(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func newTestOIDC(t *testing.T, f *jwksFixture) *OIDCAuthenticator {
+	t.Helper()
+	a, err := NewOIDCAuthenticator(OIDCConfig{
+		Issuer:   f.server.URL,
+		Audience: "rancher-mcp",
+	})
+	if err != nil {
+		t.Fatalf("NewOIDCAuthenticator failed: %v", err)
+	}
+	return a
+}
+
+func TestOIDCAcceptsValidTokens(t *testing.T) {
+	f := newJWKSFixture(t)
+	a := newTestOIDC(t, f)
+
+	for _, tc := range []struct {
+		alg string
+		kid string
+	}{
+		{"RS256", "rsa-1"},
+		{"ES256", "ec-1"},
+	} {
+		t.Run(tc.alg, func(t *testing.T) {
+			id, err := a.Authenticate(requestWithToken(f.sign(t, tc.alg, tc.kid, f.claims(nil))))
+			if err != nil {
+				t.Fatalf("Authenticate failed: %v", err)
+			}
+			if id.Subject != "alice" || id.Method != "oidc" {
+				t.Errorf("unexpected identity %+v", id)
+			}
+			if strings.Join(id.Groups, ",") != "operators,readers" {
+				t.Errorf("unexpected groups %v", id.Groups)
+			}
+		})
+	}
+
+	// Discovery and the key set are fetched once and then served from cache
+	if got := f.fetches.Load(); got != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", got)
+	}
+}
+
+func TestOIDCRejectsInvalidTokens(t *testing.T) {
+	f := newJWKSFixture(t)
+	a := newTestOIDC(t, f)
+
+	valid := f.sign(t, "RS256", "rsa-1", f.claims(nil))
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + b64([]byte(`{"iss":"`+f.server.URL+`","sub":"mallory","aud":"rancher-mcp","exp":9999999999}`)) + "." + parts[2]
+
+	tests := map[string]string{
+		"expired":        f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"not yet valid":  f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"no expiry":      f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"exp": nil})),
+		"wrong issuer":   f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+		"wrong audience": f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"aud": "someone-else"})),
+		"no audience":    f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"aud": nil})),
+		"no subject":     f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{"sub": nil})),
+		"tampered":       tampered,
+		"alg none":       f.sign(t, "none", "rsa-1", f.claims(nil)),
+		"alg mismatch":   strings.Replace(f.sign(t, "ES256", "ec-1", f.claims(nil)), b64([]byte(`{"alg":"ES256","kid":"ec-1","typ":"JWT"}`)), b64([]byte(`{"alg":"RS256","kid":"ec-1","typ":"JWT"}`)), 1),
+		"unknown key":    f.sign(t, "RS256", "rsa-2", f.claims(nil)),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(requestWithToken(token))
+			if !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOIDCRequiresAudience(t *testing.T) {
+	if _, err := NewOIDCAuthenticator(OIDCConfig{Issuer: "https://issuer.example.com"}); err == nil {
+		t.Error("NewOIDCAuthenticator accepted a config without an audience")
+	}
+}
+
+func TestOIDCIgnoresNonJWTCredentials(t *testing.T) {
+	f := newJWKSFixture(t)
+	a := newTestOIDC(t, f)
+
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/mcp", nil),
+		requestWithToken("plain-api-key"),
+	} {
+		if _, err := a.Authenticate(r); !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected ErrNoCredentials, got %v", err)
+		}
+	}
+	if got := f.fetches.Load(); got != 0 {
+		t.Errorf("JWKS fetched %d times for non-JWT credentials, want 0", got)
+	}
+}
+
+func TestOIDCRefreshesKeysRateLimited(t *testing.T) {
+	f := newJWKSFixture(t)
+	a := newTestOIDC(t, f)
+
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	if _, err := a.Authenticate(requestWithToken(f.sign(t, "RS256", "rsa-1", f.claims(nil)))); err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	// Unknown key IDs trigger at most one refetch per interval
+	unknown := f.sign(t, "RS256", "rotated", f.claims(nil))
+	now = now.Add(2 * jwksMinRefreshInterval)
+	for i := 0; i < 3; i++ {
+		a.Authenticate(requestWithToken(unknown))
+	}
+	if got := f.fetches.Load(); got != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", got)
+	}
+
+	// An expired cache is refreshed on the next request
+	now = now.Add(jwksCacheTTL + time.Minute)
+	if _, err := a.Authenticate(requestWithToken(f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+	})))); err != nil {
+		t.Fatalf("Authenticate after TTL failed: %v", err)
+	}
+	if got := f.fetches.Load(); got != 3 {
+		t.Errorf("JWKS fetched %d times, want 3", got)
+	}
+}
+
+// holdingTransport blocks requests while hold is set, until release is closed
+type holdingTransport struct {
+	hold    atomic.Bool
+	held    chan struct{}
+	release chan struct{}
+}
+
+func (h *holdingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if h.hold.Load() {
+		h.held <- struct{}{}
+		<-h.release
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestOIDCRefreshDoesNotBlockAuthentication(t *testing.T) {
+	f := newJWKSFixture(t)
+	a := newTestOIDC(t, f)
+	transport := &holdingTransport{held: make(chan struct{}, 1), release: make(chan struct{})}
+	a.config.HTTPClient = &http.Client{Transport: transport}
+
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	if _, err := a.Authenticate(requestWithToken(f.sign(t, "RS256", "rsa-1", f.claims(nil)))); err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+
+	// Once the cache is stale, the next request refreshes it and hangs
+	now = now.Add(jwksCacheTTL + time.Minute)
+	token := f.sign(t, "RS256", "rsa-1", f.claims(map[string]interface{}{
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+	}))
+	transport.hold.Store(true)
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(requestWithToken(token))
+		refreshed <- err
+	}()
+	<-transport.held
+
+	// Meanwhile other requests are served from the cached keys
+	served := make(chan error, 1)
+	go func() {
+		_, err := a.Authenticate(requestWithToken(token))
+		served <- err
+	}()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Authenticate during a refresh failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Authenticate waited for the key refresh")
+	}
+
+	transport.hold.Store(false)
+	close(transport.release)
+	if err := <-refreshed; err != nil {
+		t.Errorf("Authenticate that refreshed the keys failed: %v", err)
+	}
+}
+
+func TestVerifySignatureKeyMismatch(t *testing.T) {
+	f := newJWKSFixture(t)
+	tests := map[string]struct {
+		alg string
+		key crypto.PublicKey
+	}{
+		"ES384 with a P-256 key": {"ES384", &f.ecKey.PublicKey},
+		"RS256 with an EC key":   {"RS256", &f.ecKey.PublicKey},
+		"ES256 with an RSA key":  {"ES256", &f.rsaKey.PublicKey},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := verifySignature(tt.alg, tt.key, "header.claims", make([]byte, 96))
+			if err == nil || !strings.Contains(err.Error(), "does not match") {
+				t.Errorf("verifySignature = %v, want a key mismatch", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// Policy decides which tools each identity may use. A policy file looks like:
+//
+//	{"rules": [
+//	  {"groups": ["oidc:rancher-admins"], "allow": ["*"]},
+//	  {"subjects": ["apikey:ci-bot"], "allow": ["create_project"]},
+//	  {"subjects": ["*"], "allow": ["list_*", "get_*"], "deny": ["get_kubeconfig"]}
+//	]}
+//
+// Rules apply to identities whose subject or one of whose groups they list.
+// Subjects and groups are qualified by the authentication method, as
+// "apikey:<name>" or "oidc:<claim>", so that a rule for an API key never
+// matches an OIDC user of the same name. "*" in subjects matches every
+// identity and "<method>:*" every identity of that method. A tool is allowed
+// when an applicable rule allows it and no applicable rule denies it, so
+// anything not allowed is denied. Tool patterns use path.Match syntax.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule grants or withholds tools from the identities it matches
+type PolicyRule struct {
+	Subjects []string `json:"subjects,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Allow    []string `json:"allow,omitempty"`
+	Deny     []string `json:"deny,omitempty"`
+}
+
+// LoadPolicy reads and validates a policy file
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("policy file %s: %w", file, err)
+	}
+	return &policy, nil
+}
+
+func (p *Policy) validate() error {
+	for i, rule := range p.Rules {
+		if len(rule.Subjects) == 0 && len(rule.Groups) == 0 {
+			return fmt.Errorf("rule %d matches no subjects or groups", i)
+		}
+		for _, name := range append(append([]string(nil), rule.Subjects...), rule.Groups...) {
+			if method, value, ok := strings.Cut(name, ":"); name != "*" && (!ok || method == "" || value == "") {
+				return fmt.Errorf("rule %d: %q must be qualified by its authentication method, as apikey:<name> or oidc:<name>", i, name)
+			}
+		}
+		for _, pattern := range append(append([]string(nil), rule.Allow...), rule.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d: invalid tool pattern %q", i, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// Allowed reports whether id may use tool
+func (p *Policy) Allowed(id *Identity, tool string) bool {
+	allowed := false
+	for _, rule := range p.Rules {
+		if !rule.appliesTo(id) {
+			continue
+		}
+		if matchAny(rule.Deny, tool) {
+			return false
+		}
+		if matchAny(rule.Allow, tool) {
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+func (rule *PolicyRule) appliesTo(id *Identity) bool {
+	for _, subject := range rule.Subjects {
+		if subject == "*" || subject == id.Qualified("*") || subject == id.Qualified(id.Subject) {
+			return true
+		}
+	}
+	for _, group := range rule.Groups {
+		for _, idGroup := range id.Groups {
+			if group == id.Qualified(idGroup) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package auth
+
+import "testing"
+
+func TestPolicyAllowed(t *testing.T) {
+	file := writeFile(t, "policy.json", `{"rules": [
+		{"groups": ["oidc:admins"], "allow": ["*"]},
+		{"subjects": ["*"], "allow": ["list_*", "get_*"], "deny": ["get_kubeconfig"]},
+		{"subjects": ["apikey:ci-bot"], "allow": ["create_project"]},
+		{"subjects": ["oidc:*"], "allow": ["update_user"]}
+	]}`)
+	policy, err := LoadPolicy(file)
+	if err != nil {
+		t.Fatalf("LoadPolicy failed: %v", err)
+	}
+
+	admin := &Identity{Subject: "alice", Groups: []string{"admins"}, Method: "oidc"}
+	bot := &Identity{Subject: "ci-bot", Method: "apikey"}
+	other := &Identity{Subject: "bob", Groups: []string{"readers"}, Method: "oidc"}
+	// An OIDC user named like the API key, in a group named like the admins
+	// group of API keys, gets neither rule
+	impostor := &Identity{Subject: "ci-bot", Method: "oidc"}
+	keyAdmin := &Identity{Subject: "carol", Groups: []string{"admins"}, Method: "apikey"}
+
+	tests := []struct {
+		id      *Identity
+		tool    string
+		allowed bool
+	}{
+		{admin, "delete_cluster", true},
+		// Deny rules win even over another rule's wildcard allow
+		{admin, "get_kubeconfig", false},
+		{bot, "create_project", true},
+		{bot, "list_clusters", true},
+		{bot, "delete_project", false},
+		{bot, "update_user", false},
+		{other, "get_user", true},
+		{other, "update_user", true},
+		{other, "create_project", false},
+		{other, "get_kubeconfig", false},
+		{impostor, "create_project", false},
+		{keyAdmin, "delete_cluster", false},
+	}
+	for _, tc := range tests {
+		if got := policy.Allowed(tc.id, tc.tool); got != tc.allowed {
+			t.Errorf("Allowed(%s, %s) = %v, want %v", tc.id.Qualified(tc.id.Subject), tc.tool, got, tc.allowed)
+		}
+	}
+}
+
+func TestLoadPolicyRejectsInvalidRules(t *testing.T) {
+	for name, content := range map[string]string{
+		"no match":     `{"rules": [{"allow": ["*"]}]}`,
+		"bad pattern":  `{"rules": [{"subjects": ["*"], "allow": ["list_["]}]}`,
+		"bare subject": `{"rules": [{"subjects": ["ci-bot"], "allow": ["*"]}]}`,
+		"bare group":   `{"rules": [{"groups": ["admins"], "allow": ["*"]}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadPolicy(writeFile(t, "policy.json", content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
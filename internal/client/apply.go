@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// objectPaths maps the apiVersion and kind of the objects this client
+// manages to their API paths
+var objectPaths = map[string]func(name, namespace string) string{
+	"management.cattle.io/v3/Cluster":                    func(name, _ string) string { return ClusterPath(name) },
+	"management.cattle.io/v3/User":                       func(name, _ string) string { return UserPath(name) },
+	"management.cattle.io/v3/Project":                    ProjectPath,
+	"management.cattle.io/v3/GlobalRole":                 func(name, _ string) string { return GlobalRolePath(name) },
+	"management.cattle.io/v3/GlobalRoleBinding":          func(name, _ string) string { return GlobalRoleBindingPath(name) },
+	"management.cattle.io/v3/RoleTemplate":               func(name, _ string) string { return RoleTemplatePath(name) },
+	"management.cattle.io/v3/ClusterRoleTemplateBinding": ClusterRoleTemplateBindingPath,
+	"management.cattle.io/v3/ProjectRoleTemplateBinding": ProjectRoleTemplateBindingPath,
+	"auditlog.cattle.io/v1/AuditPolicy":                  func(name, _ string) string { return AuditPolicyPath(name) },
+	"ext.cattle.io/v1/Token":                             func(name, _ string) string { return TokenPath(name) },
+	"ext.cattle.io/v1/Kubeconfig":                        func(name, _ string) string { return KubeconfigPath(name) },
+}
+
+// ObjectPath is the API path of obj, found from its apiVersion, kind and
+// metadata
+func ObjectPath(obj map[string]interface{}) (string, error) {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	if apiVersion == "" || kind == "" || name == "" {
+		return "", fmt.Errorf("object must have apiVersion, kind and metadata.name")
+	}
+	path, ok := objectPaths[apiVersion+"/"+kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported object type %s %s", apiVersion, kind)
+	}
+	return path(name, namespace), nil
+}
+
+// Apply creates or updates obj with server-side apply. Rancher records
+// FieldManager as the owner of the fields in obj; applying again later
+// without a field removes it, while fields set by others are left alone.
+// With opts.Force, fields owned by another manager are taken over instead of
+// failing with a conflict. opts.Type is ignored.
+func (c *RancherClient) Apply(ctx context.Context, obj map[string]interface{}, opts PatchOptions) (interface{}, error) {
+	apiPath, err := ObjectPath(obj)
+	if err != nil {
+		return nil, err
+	}
+	opts.Type = ApplyPatch
+	return c.patchResource(ctx, apiPath, obj, opts)
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// patchRequest is what recordingServer saw of the last request
+type patchRequest struct {
+	method      string
+	path        string
+	query       string
+	contentType string
+	body        interface{}
+}
+
+func recordingServer(t *testing.T) (*RancherClient, *patchRequest) {
+	t.Helper()
+	var got patchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = patchRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery, contentType: r.Header.Get("Content-Type")}
+		json.NewDecoder(r.Body).Decode(&got.body)
+		w.Write([]byte(`{"kind":"GlobalRole","metadata":{"name":"admin"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return NewRancherClient(server.URL, "token", false), &got
+}
+
+func TestPatchTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        PatchOptions
+		patch       interface{}
+		contentType string
+		query       string
+	}{
+		{
+			name:        "merge by default",
+			patch:       map[string]interface{}{"description": "x"},
+			contentType: "application/merge-patch+json",
+		},
+		{
+			name:        "json",
+			opts:        PatchOptions{Type: JSONPatch},
+			patch:       []interface{}{map[string]interface{}{"op": "remove", "path": "/rules/1"}},
+			contentType: "application/json-patch+json",
+		},
+		{
+			name:        "apply",
+			opts:        PatchOptions{Type: ApplyPatch},
+			patch:       map[string]interface{}{"apiVersion": "management.cattle.io/v3", "kind": "GlobalRole"},
+			contentType: "application/apply-patch+yaml",
+			query:       "fieldManager=rancher-mcp",
+		},
+		{
+			name:        "forced apply dry run",
+			opts:        PatchOptions{Type: ApplyPatch, Force: true, DryRun: true},
+			patch:       map[string]interface{}{"apiVersion": "management.cattle.io/v3", "kind": "GlobalRole"},
+			contentType: "application/apply-patch+yaml",
+			query:       "dryRun=All&fieldManager=rancher-mcp&force=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, got := recordingServer(t)
+			if _, err := c.PatchGlobalRole(context.Background(), "admin", tt.patch, tt.opts); err != nil {
+				t.Fatalf("PatchGlobalRole failed: %v", err)
+			}
+			if got.method != http.MethodPatch || got.path != GlobalRolePath("admin") {
+				t.Errorf("request = %s %s, want PATCH %s", got.method, got.path, GlobalRolePath("admin"))
+			}
+			if got.contentType != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got.contentType, tt.contentType)
+			}
+			if got.query != tt.query {
+				t.Errorf("query = %q, want %q", got.query, tt.query)
+			}
+		})
+	}
+}
+
+func TestPatchUnknownType(t *testing.T) {
+	c, _ := recordingServer(t)
+	if _, err := c.PatchGlobalRole(context.Background(), "admin", map[string]interface{}{}, PatchOptions{Type: "strategic"}); err == nil {
+		t.Fatal("PatchGlobalRole accepted an unknown patch type")
+	}
+}
+
+func TestApply(t *testing.T) {
+	c, got := recordingServer(t)
+	obj := map[string]interface{}{
+		"apiVersion": "management.cattle.io/v3",
+		"kind":       "ProjectRoleTemplateBinding",
+		"metadata":   map[string]interface{}{"name": "prtb-1", "namespace": "p-abc"},
+	}
+
+	if _, err := c.Apply(context.Background(), obj, PatchOptions{Type: MergePatch, Force: true}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if want := ProjectRoleTemplateBindingPath("prtb-1", "p-abc"); got.path != want {
+		t.Errorf("path = %s, want %s", got.path, want)
+	}
+	if got.contentType != "application/apply-patch+yaml" || got.query != "fieldManager=rancher-mcp&force=true" {
+		t.Errorf("request = %q with query %q, want a forced apply", got.contentType, got.query)
+	}
+}
+
+func TestObjectPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "cluster-scoped",
+			obj: map[string]interface{}{
+				"apiVersion": "ext.cattle.io/v1", "kind": "Token",
+				"metadata": map[string]interface{}{"name": "token-1"},
+			},
+			want: "/apis/ext.cattle.io/v1/tokens/token-1",
+		},
+		{
+			name: "namespaced",
+			obj: map[string]interface{}{
+				"apiVersion": "management.cattle.io/v3", "kind": "Project",
+				"metadata": map[string]interface{}{"name": "p-abc", "namespace": "c-xyz"},
+			},
+			want: "/apis/management.cattle.io/v3/namespaces/c-xyz/projects/p-abc",
+		},
+		{
+			name: "unsupported kind",
+			obj: map[string]interface{}{
+				"apiVersion": "v1", "kind": "ConfigMap",
+				"metadata": map[string]interface{}{"name": "cm"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no name",
+			obj:     map[string]interface{}{"apiVersion": "management.cattle.io/v3", "kind": "User"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ObjectPath(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ObjectPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
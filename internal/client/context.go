@@ -0,0 +1,17 @@
+package client
+
+import "context"
+
+type clientContextKey struct{}
+
+// NewContext returns a copy of ctx carrying c, so that handlers act with the
+// credentials of the caller instead of the server's own
+func NewContext(ctx context.Context, c *RancherClient) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, c)
+}
+
+// FromContext returns the client attached to ctx, if any
+func FromContext(ctx context.Context) (*RancherClient, bool) {
+	c, ok := ctx.Value(clientContextKey{}).(*RancherClient)
+	return c, ok && c != nil
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLength caps how much of a response that is not a Kubernetes
+// Status is kept in an APIError
+const maxErrorBodyLength = 512
+
+// APIError is a non-2xx response from the Rancher API. Kubernetes-style
+// endpoints describe the failure with a Status object, which is decoded into
+// Reason, Message and Details.
+type APIError struct {
+	StatusCode int
+	// Reason is the machine-readable reason from the Status, such as
+	// "NotFound" or "AlreadyExists". It is empty for other responses.
+	Reason  string
+	Message string
+	Details *StatusDetails
+	// Body holds the start of the response when it is not a Status
+	Body string
+
+	// retryAfter is the Retry-After header of the response, in seconds
+	retryAfter int
+}
+
+// StatusDetails identifies the object a Status is about and the causes of
+// the failure
+type StatusDetails struct {
+	Name              string        `json:"name,omitempty"`
+	Group             string        `json:"group,omitempty"`
+	Kind              string        `json:"kind,omitempty"`
+	Causes            []StatusCause `json:"causes,omitempty"`
+	RetryAfterSeconds int           `json:"retryAfterSeconds,omitempty"`
+}
+
+// StatusCause is a single reason for a failure, often an invalid field
+type StatusCause struct {
+	Type    string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// newAPIError decodes the body of a failed response
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var status struct {
+		Kind    string         `json:"kind"`
+		Reason  string         `json:"reason"`
+		Message string         `json:"message"`
+		Details *StatusDetails `json:"details"`
+	}
+	if err := json.Unmarshal(body, &status); err == nil && status.Kind == "Status" {
+		apiErr.Reason = status.Reason
+		apiErr.Message = status.Message
+		apiErr.Details = status.Details
+		return apiErr
+	}
+
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxErrorBodyLength {
+		text = text[:maxErrorBodyLength] + "..."
+	}
+	apiErr.Body = text
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Message != "" && e.Reason != "":
+		return fmt.Sprintf("API error: %d %s - %s", e.StatusCode, e.Reason, e.Message)
+	case e.Message != "":
+		return fmt.Sprintf("API error: %d - %s", e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("API error: %d - %s", e.StatusCode, e.Body)
+	}
+}
+
+// RetryAfterSeconds is how long the server asked the client to wait before
+// retrying, from the Status details or the Retry-After header, or 0
+func (e *APIError) RetryAfterSeconds() int {
+	if e.Details != nil && e.Details.RetryAfterSeconds > 0 {
+		return e.Details.RetryAfterSeconds
+	}
+	return e.retryAfter
+}
+
+// hasReason reports whether err is an APIError with the given Status reason,
+// or, for responses without a Status, the given HTTP code
+func hasReason(err error, reason string, code int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Reason != "" {
+		return apiErr.Reason == reason
+	}
+	return apiErr.StatusCode == code
+}
+
+// IsNotFound reports whether err means the object does not exist
+func IsNotFound(err error) bool {
+	return hasReason(err, "NotFound", http.StatusNotFound)
+}
+
+// IsAlreadyExists reports whether err means an object with that name exists.
+// Only the Status reason says so: a 409 without one is treated as a
+// conflict by IsConflict.
+func IsAlreadyExists(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Reason == "AlreadyExists"
+}
+
+// IsConflict reports whether err means the object was modified since it was
+// read, so the write must be retried against the latest version
+func IsConflict(err error) bool {
+	return hasReason(err, "Conflict", http.StatusConflict)
+}
+
+// IsForbidden reports whether err means the token lacks permission
+func IsForbidden(err error) bool {
+	return hasReason(err, "Forbidden", http.StatusForbidden)
+}
+
+// IsUnauthorized reports whether err means the token was not accepted
+func IsUnauthorized(err error) bool {
+	return hasReason(err, "Unauthorized", http.StatusUnauthorized)
+}
+
+// IsInvalid reports whether err means the object failed validation
+func IsInvalid(err error) bool {
+	return hasReason(err, "Invalid", http.StatusUnprocessableEntity)
+}
+
+// IsTooManyRequests reports whether err means the server is rate limiting
+func IsTooManyRequests(err error) bool {
+	return hasReason(err, "TooManyRequests", http.StatusTooManyRequests)
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	body := []byte(`{"kind":"Status","status":"Failure","reason":"NotFound","message":"clusters.management.cattle.io \"c-x\" not found","details":{"name":"c-x","group":"management.cattle.io","kind":"clusters"},"code":404}`)
+	err := newAPIError(http.StatusNotFound, body)
+
+	if err.Reason != "NotFound" || err.Details == nil || err.Details.Name != "c-x" {
+		t.Fatalf("newAPIError did not decode the Status: %+v", err)
+	}
+	if want := `API error: 404 NotFound - clusters.management.cattle.io "c-x" not found`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !IsNotFound(fmt.Errorf("get cluster: %w", err)) {
+		t.Error("IsNotFound is false for a wrapped NotFound error")
+	}
+	if IsConflict(err) {
+		t.Error("IsConflict is true for a NotFound error")
+	}
+}
+
+func TestNewAPIErrorWithoutStatus(t *testing.T) {
+	err := newAPIError(http.StatusBadGateway, []byte(strings.Repeat("x", 1000)))
+
+	if err.Reason != "" || err.Message != "" {
+		t.Errorf("newAPIError decoded a Status from a plain body: %+v", err)
+	}
+	if len(err.Body) != maxErrorBodyLength+len("...") {
+		t.Errorf("Body has %d bytes, want it truncated to %d", len(err.Body), maxErrorBodyLength)
+	}
+
+	// Without a Status reason the HTTP code decides
+	plainConflict := newAPIError(http.StatusConflict, []byte("conflict"))
+	if !IsConflict(plainConflict) {
+		t.Error("IsConflict is false for a plain 409")
+	}
+	if IsAlreadyExists(plainConflict) {
+		t.Error("IsAlreadyExists is true for a plain 409")
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	err := newAPIError(http.StatusTooManyRequests, []byte(`{"kind":"Status","reason":"TooManyRequests","details":{"retryAfterSeconds":7}}`))
+	err.retryAfter = 3
+	if got := err.RetryAfterSeconds(); got != 7 {
+		t.Errorf("RetryAfterSeconds() = %d, want 7 from the Status details", got)
+	}
+
+	err = newAPIError(http.StatusServiceUnavailable, nil)
+	err.retryAfter = 3
+	if got := err.RetryAfterSeconds(); got != 3 {
+		t.Errorf("RetryAfterSeconds() = %d, want 3 from the header", got)
+	}
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	notFound := fmt.Errorf("wrapped: %w", &APIError{StatusCode: 404, Reason: "NotFound"})
+	if !IsNotFound(notFound) || IsForbidden(notFound) || IsConflict(notFound) {
+		t.Error("wrapped NotFound misclassified")
+	}
+	if IsNotFound(errors.New("404")) {
+		t.Error("plain error classified as NotFound")
+	}
+	if err := (&APIError{StatusCode: 404, Reason: "NotFound", Message: "gone"}); !strings.Contains(err.Error(), "404 NotFound - gone") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// WriteOptions controls create, update and delete requests
+type WriteOptions struct {
+	// DryRun sends the request with dryRun=All. Rancher validates and admits
+	// it and returns the object as it would be persisted, but stores nothing.
+	DryRun bool
+}
+
+func (o WriteOptions) query() url.Values {
+	query := url.Values{}
+	if o.DryRun {
+		query.Set("dryRun", "All")
+	}
+	return query
+}
+
+// ListOptions narrows a list request. Zero values are left out of the query.
+type ListOptions struct {
+	// Limit caps the number of items returned. When more remain, the list
+	// metadata carries a continue token for the next page.
+	Limit int64
+	// Continue is the token from the previous page
+	Continue      string
+	LabelSelector string
+	FieldSelector string
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.FormatInt(o.Limit, 10))
+	}
+	if o.Continue != "" {
+		query.Set("continue", o.Continue)
+	}
+	if o.LabelSelector != "" {
+		query.Set("labelSelector", o.LabelSelector)
+	}
+	if o.FieldSelector != "" {
+		query.Set("fieldSelector", o.FieldSelector)
+	}
+	return query
+}
+
+// PatchType selects how Rancher applies a patch
+type PatchType string
+
+const (
+	// MergePatch is an RFC 7386 JSON merge patch. Maps are merged, but arrays
+	// such as the rules of a role are replaced as a whole.
+	MergePatch PatchType = "merge"
+	// JSONPatch is a list of RFC 6902 operations, which can add, replace or
+	// remove single array items
+	JSONPatch PatchType = "json"
+	// ApplyPatch is a server-side apply of a partial object. The fields it
+	// sets become owned by FieldManager.
+	ApplyPatch PatchType = "apply"
+)
+
+// FieldManager is the field manager recorded for server-side apply
+const FieldManager = "rancher-mcp"
+
+const jsonPatchContentType = "application/json-patch+json"
+
+// contentType is the Content-Type of patches of type t
+func (t PatchType) contentType() (string, error) {
+	switch t {
+	case MergePatch, "":
+		return "application/merge-patch+json", nil
+	case JSONPatch:
+		return jsonPatchContentType, nil
+	case ApplyPatch:
+		// JSON is valid YAML
+		return "application/apply-patch+yaml", nil
+	default:
+		return "", fmt.Errorf("unknown patch type %q: use merge, json or apply", t)
+	}
+}
+
+// PatchOptions controls patch requests. The zero value sends merge patches.
+type PatchOptions struct {
+	Type PatchType
+	// Force makes a server-side apply take ownership of fields that another
+	// field manager set, instead of failing with a conflict
+	Force bool
+	// DryRun sends the patch with dryRun=All, as for WriteOptions
+	DryRun bool
+}
+
+func (o PatchOptions) query() url.Values {
+	query := WriteOptions{DryRun: o.DryRun}.query()
+	if o.Type == ApplyPatch {
+		query.Set("fieldManager", FieldManager)
+		if o.Force {
+			query.Set("force", "true")
+		}
+	}
+	return query
+}
+
+// withQuery appends query to apiPath
+func withQuery(apiPath string, query url.Values) string {
+	if len(query) == 0 {
+		return apiPath
+	}
+	if strings.Contains(apiPath, "?") {
+		return apiPath + "&" + query.Encode()
+	}
+	return apiPath + "?" + query.Encode()
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestOptionQueries(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		want  string
+	}{
+		{"write", WriteOptions{}.query(), ""},
+		{"write dry run", WriteOptions{DryRun: true}.query(), "dryRun=All"},
+		{"list", ListOptions{}.query(), ""},
+		{
+			name:  "list narrowed",
+			query: ListOptions{Limit: 10, Continue: "abc", LabelSelector: "app=web", FieldSelector: "metadata.name=local"}.query(),
+			want:  "continue=abc&fieldSelector=metadata.name%3Dlocal&labelSelector=app%3Dweb&limit=10",
+		},
+		{"merge patch", PatchOptions{}.query(), ""},
+		{"merge patch dry run", PatchOptions{Type: MergePatch, DryRun: true}.query(), "dryRun=All"},
+		{"force without apply", PatchOptions{Type: JSONPatch, Force: true}.query(), ""},
+		{"apply", PatchOptions{Type: ApplyPatch}.query(), "fieldManager=rancher-mcp"},
+		{"forced apply dry run", PatchOptions{Type: ApplyPatch, Force: true, DryRun: true}.query(), "dryRun=All&fieldManager=rancher-mcp&force=true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Encode(); got != tt.want {
+				t.Errorf("query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	dryRun := WriteOptions{DryRun: true}.query()
+	tests := []struct {
+		path  string
+		query url.Values
+		want  string
+	}{
+		{"/v1/test", nil, "/v1/test"},
+		{"/v1/test", dryRun, "/v1/test?dryRun=All"},
+		{"/v1/test?watch=1", dryRun, "/v1/test?watch=1&dryRun=All"},
+	}
+	for _, tt := range tests {
+		if got := withQuery(tt.path, tt.query); got != tt.want {
+			t.Errorf("withQuery(%q, %v) = %q, want %q", tt.path, tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	ctx := context.Background()
+	dryRun := WriteOptions{DryRun: true}
+	tests := []struct {
+		name   string
+		call   func(c *RancherClient) (interface{}, error)
+		method string
+		query  string
+	}{
+		{
+			name: "create",
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.CreateGlobalRole(ctx, map[string]interface{}{}, dryRun)
+			},
+			method: http.MethodPost,
+			query:  "dryRun=All",
+		},
+		{
+			name: "update",
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.UpdateGlobalRole(ctx, "admin", map[string]interface{}{}, dryRun)
+			},
+			method: http.MethodPut,
+			query:  "dryRun=All",
+		},
+		{
+			name:   "delete",
+			call:   func(c *RancherClient) (interface{}, error) { return c.DeleteGlobalRole(ctx, "admin", dryRun) },
+			method: http.MethodDelete,
+			query:  "dryRun=All",
+		},
+		{
+			name:   "delete without options",
+			call:   func(c *RancherClient) (interface{}, error) { return c.DeleteGlobalRole(ctx, "admin", WriteOptions{}) },
+			method: http.MethodDelete,
+		},
+		{
+			name: "apply dry run",
+			call: func(c *RancherClient) (interface{}, error) {
+				obj := map[string]interface{}{"apiVersion": "management.cattle.io/v3", "kind": "GlobalRole", "metadata": map[string]interface{}{"name": "admin"}}
+				return c.Apply(ctx, obj, PatchOptions{DryRun: true})
+			},
+			method: http.MethodPatch,
+			query:  "dryRun=All&fieldManager=rancher-mcp",
+		},
+		{
+			name:   "list",
+			call:   func(c *RancherClient) (interface{}, error) { return c.ListGlobalRoles(ctx, ListOptions{Limit: 5}) },
+			method: http.MethodGet,
+			query:  "limit=5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, got := recordingServer(t)
+			if _, err := tt.call(c); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if got.method != tt.method || got.query != tt.query {
+				t.Errorf("request = %s ?%s, want %s ?%s", got.method, got.query, tt.method, tt.query)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import "fmt"
+
+// API paths of the collections and objects this client manages. Every
+// request path is built here.
+
+const (
+	managementAPI = "/apis/management.cattle.io/v3"
+	auditlogAPI   = "/apis/auditlog.cattle.io/v1"
+	extAPI        = "/apis/ext.cattle.io/v1"
+)
+
+// collectionPath is the path of resource in the API group at apiPath,
+// optionally in namespace
+func collectionPath(apiPath, resource, namespace string) string {
+	if namespace != "" {
+		return fmt.Sprintf("%s/namespaces/%s/%s", apiPath, namespace, resource)
+	}
+	return fmt.Sprintf("%s/%s", apiPath, resource)
+}
+
+// ClustersPath is the API path of the clusters collection
+func ClustersPath() string {
+	return collectionPath(managementAPI, "clusters", "")
+}
+
+// ClusterPath is the API path of a cluster
+func ClusterPath(name string) string {
+	return ClustersPath() + "/" + name
+}
+
+// UsersPath is the API path of the users collection
+func UsersPath() string {
+	return collectionPath(managementAPI, "users", "")
+}
+
+// UserPath is the API path of a user
+func UserPath(name string) string {
+	return UsersPath() + "/" + name
+}
+
+// ProjectsPath is the API path of the projects collection, optionally in
+// namespace
+func ProjectsPath(namespace string) string {
+	return collectionPath(managementAPI, "projects", namespace)
+}
+
+// ProjectPath is the API path of a project, optionally in namespace
+func ProjectPath(name, namespace string) string {
+	return ProjectsPath(namespace) + "/" + name
+}
+
+// AuditPoliciesPath is the API path of the audit policies collection
+func AuditPoliciesPath() string {
+	return collectionPath(auditlogAPI, "auditpolicies", "")
+}
+
+// AuditPolicyPath is the API path of an audit policy
+func AuditPolicyPath(name string) string {
+	return AuditPoliciesPath() + "/" + name
+}
+
+// KubeconfigsPath is the API path of the kubeconfigs collection
+func KubeconfigsPath() string {
+	return collectionPath(extAPI, "kubeconfigs", "")
+}
+
+// KubeconfigPath is the API path of a kubeconfig
+func KubeconfigPath(name string) string {
+	return KubeconfigsPath() + "/" + name
+}
+
+// TokensPath is the API path of the tokens collection
+func TokensPath() string {
+	return collectionPath(extAPI, "tokens", "")
+}
+
+// TokenPath is the API path of a token
+func TokenPath(name string) string {
+	return TokensPath() + "/" + name
+}
+
+// GlobalRolesPath is the API path of the global roles collection
+func GlobalRolesPath() string {
+	return collectionPath(managementAPI, "globalroles", "")
+}
+
+// GlobalRolePath is the API path of a global role
+func GlobalRolePath(name string) string {
+	return GlobalRolesPath() + "/" + name
+}
+
+// GlobalRoleBindingsPath is the API path of the global role bindings
+// collection
+func GlobalRoleBindingsPath() string {
+	return collectionPath(managementAPI, "globalrolebindings", "")
+}
+
+// GlobalRoleBindingPath is the API path of a global role binding
+func GlobalRoleBindingPath(name string) string {
+	return GlobalRoleBindingsPath() + "/" + name
+}
+
+// RoleTemplatesPath is the API path of the role templates collection
+func RoleTemplatesPath() string {
+	return collectionPath(managementAPI, "roletemplates", "")
+}
+
+// RoleTemplatePath is the API path of a role template
+func RoleTemplatePath(name string) string {
+	return RoleTemplatesPath() + "/" + name
+}
+
+// ClusterRoleTemplateBindingsPath is the API path of the cluster role
+// template bindings collection, optionally in namespace
+func ClusterRoleTemplateBindingsPath(namespace string) string {
+	return collectionPath(managementAPI, "clusterroletemplatebindings", namespace)
+}
+
+// ClusterRoleTemplateBindingPath is the API path of a cluster role template
+// binding, optionally in namespace
+func ClusterRoleTemplateBindingPath(name, namespace string) string {
+	return ClusterRoleTemplateBindingsPath(namespace) + "/" + name
+}
+
+// ProjectRoleTemplateBindingsPath is the API path of the project role
+// template bindings collection, optionally in namespace
+func ProjectRoleTemplateBindingsPath(namespace string) string {
+	return collectionPath(managementAPI, "projectroletemplatebindings", namespace)
+}
+
+// ProjectRoleTemplateBindingPath is the API path of a project role template
+// binding, optionally in namespace
+func ProjectRoleTemplateBindingPath(name, namespace string) string {
+	return ProjectRoleTemplateBindingsPath(namespace) + "/" + name
+}
@@ -0,0 +1,24 @@
+package client
+
+import "testing"
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{ClustersPath(), "/apis/management.cattle.io/v3/clusters"},
+		{ClusterPath("c-1"), "/apis/management.cattle.io/v3/clusters/c-1"},
+		{ProjectsPath(""), "/apis/management.cattle.io/v3/projects"},
+		{ProjectsPath("c-1"), "/apis/management.cattle.io/v3/namespaces/c-1/projects"},
+		{ProjectPath("p-1", "c-1"), "/apis/management.cattle.io/v3/namespaces/c-1/projects/p-1"},
+		{AuditPolicyPath("a-1"), "/apis/auditlog.cattle.io/v1/auditpolicies/a-1"},
+		{TokensPath(), "/apis/ext.cattle.io/v1/tokens"},
+		{ClusterRoleTemplateBindingPath("b-1", ""), "/apis/management.cattle.io/v3/clusterroletemplatebindings/b-1"},
+		{ProjectRoleTemplateBindingsPath("p-1"), "/apis/management.cattle.io/v3/namespaces/p-1/projectroletemplatebindings"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("path = %s, want %s", tt.got, tt.want)
+		}
+	}
+}
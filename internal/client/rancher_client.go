@@ -18,6 +18,10 @@ type RancherClient struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+	// watchClient shares the transport but has no overall timeout, since
+	// watch streams are held open for minutes
+	watchClient *http.Client
+	retryPolicy RetryPolicy
 }
 
 func NewRancherClient(baseURL, token string, insecureSkipVerify bool) *RancherClient {
@@ -37,19 +41,64 @@ func NewRancherClient(baseURL, token string, insecureSkipVerify bool) *RancherCl
 			Timeout:   30 * time.Second,
 			Transport: transport,
 		},
+		watchClient: &http.Client{
+			Transport: transport,
+		},
+		retryPolicy: DefaultRetryPolicy,
+	}
+}
+
+// WithToken returns a client for the same Rancher server that authenticates
+// with token. It shares the connection pool of c.
+func (c *RancherClient) WithToken(token string) *RancherClient {
+	return &RancherClient{
+		baseURL:     c.baseURL,
+		token:       token,
+		httpClient:  c.httpClient,
+		watchClient: c.watchClient,
+		retryPolicy: c.retryPolicy,
 	}
 }
 
 func (c *RancherClient) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	return c.send(ctx, method, path, "application/json", body)
+}
+
+// send makes a request and returns the response body, retrying failures as
+// c.retryPolicy allows
+func (c *RancherClient) send(ctx context.Context, method, path, contentType string, body interface{}) ([]byte, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
 
-	var reqBody io.Reader
+	var payload []byte
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+		payload = jsonData
+	}
+	idempotent := isIdempotent(method, contentType, body)
+
+	for attempt := 1; ; attempt++ {
+		respBody, err := c.sendOnce(ctx, method, url, contentType, payload)
+		if err == nil || ctx.Err() != nil {
+			return respBody, err
+		}
+		delay, retry := c.retryPolicy.retryDelay(attempt, idempotent, err)
+		if !retry {
+			return nil, err
+		}
+		logrus.WithContext(ctx).Debugf("Retrying %s %s in %v (retry %d of %d): %v", method, url, delay.Round(time.Millisecond), attempt, c.retryPolicy.MaxRetries, err)
+		if !sleepContext(ctx, delay) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *RancherClient) sendOnce(ctx context.Context, method, url, contentType string, payload []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
@@ -58,13 +107,13 @@ func (c *RancherClient) doRequest(ctx context.Context, method, path string, body
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
-	logrus.Debugf("Making request: %s %s", method, url)
+	logrus.WithContext(ctx).Debugf("Making request: %s %s", method, url)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, &transportError{err: err}
 	}
 	defer resp.Body.Close()
 
@@ -74,111 +123,48 @@ func (c *RancherClient) doRequest(ctx context.Context, method, path string, body
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		apiErr := newAPIError(resp.StatusCode, respBody)
+		apiErr.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, apiErr
 	}
 
 	return respBody, nil
 }
 
 // ListClusters lists all clusters
-func (c *RancherClient) ListClusters(ctx context.Context) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", "/apis/management.cattle.io/v3/clusters", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+func (c *RancherClient) ListClusters(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ClustersPath(), opts)
 }
 
 // GetCluster gets a specific cluster
 func (c *RancherClient) GetCluster(ctx context.Context, name string) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", fmt.Sprintf("/apis/management.cattle.io/v3/clusters/%s", name), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+	return c.getResource(ctx, ClusterPath(name))
 }
 
 // ListUsers lists all users
-func (c *RancherClient) ListUsers(ctx context.Context) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", "/apis/management.cattle.io/v3/users", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+func (c *RancherClient) ListUsers(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, UsersPath(), opts)
 }
 
 // GetUser gets a specific user
 func (c *RancherClient) GetUser(ctx context.Context, name string) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", fmt.Sprintf("/apis/management.cattle.io/v3/users/%s", name), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+	return c.getResource(ctx, UserPath(name))
 }
 
 // ListProjects lists all projects
-func (c *RancherClient) ListProjects(ctx context.Context) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", "/apis/management.cattle.io/v3/projects", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+func (c *RancherClient) ListProjects(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ProjectsPath(""), opts)
 }
 
 // GetProject gets a specific project
 func (c *RancherClient) GetProject(ctx context.Context, name, namespace string) (interface{}, error) {
-	path := fmt.Sprintf("/apis/management.cattle.io/v3/projects/%s", name)
-	if namespace != "" {
-		path = fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects/%s", namespace, name)
-	}
-
-	data, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return result, nil
+	return c.getResource(ctx, ProjectPath(name, namespace))
 }
 
 // VerifyToken verifies the Rancher API token
 func (c *RancherClient) VerifyToken(ctx context.Context) error {
 	// Try to get user info or list clusters to verify token
-	_, err := c.doRequest(ctx, "GET", "/apis/management.cattle.io/v3/users", nil)
+	_, err := c.doRequest(ctx, "GET", UsersPath(), nil)
 	if err != nil {
 		return fmt.Errorf("token verification failed: %w", err)
 	}
@@ -186,8 +172,10 @@ func (c *RancherClient) VerifyToken(ctx context.Context) error {
 }
 
 // Generic list/get helpers
-func (c *RancherClient) listResource(ctx context.Context, apiPath string) (interface{}, error) {
-	data, err := c.doRequest(ctx, "GET", apiPath, nil)
+
+// listResource lists a collection narrowed by opts
+func (c *RancherClient) listResource(ctx context.Context, apiPath string, opts ListOptions) (interface{}, error) {
+	data, err := c.doRequest(ctx, "GET", withQuery(apiPath, opts.query()), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -211,110 +199,95 @@ func (c *RancherClient) getResource(ctx context.Context, apiPath string) (interf
 }
 
 // auditlogCattleIo_v1 - AuditPolicy
-func (c *RancherClient) ListAuditPolicies(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/auditlog.cattle.io/v1/auditpolicies")
+func (c *RancherClient) ListAuditPolicies(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, AuditPoliciesPath(), opts)
 }
 
 func (c *RancherClient) GetAuditPolicy(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/auditlog.cattle.io/v1/auditpolicies/%s", name))
+	return c.getResource(ctx, AuditPolicyPath(name))
 }
 
 // extCattleIo_v1 - Kubeconfig
-func (c *RancherClient) ListKubeconfigs(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/ext.cattle.io/v1/kubeconfigs")
+func (c *RancherClient) ListKubeconfigs(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, KubeconfigsPath(), opts)
 }
 
 func (c *RancherClient) GetKubeconfig(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/kubeconfigs/%s", name))
+	return c.getResource(ctx, KubeconfigPath(name))
 }
 
 // extCattleIo_v1 - Token
-func (c *RancherClient) ListTokens(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/ext.cattle.io/v1/tokens")
+func (c *RancherClient) ListTokens(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, TokensPath(), opts)
 }
 
 func (c *RancherClient) GetToken(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/tokens/%s", name))
+	return c.getResource(ctx, TokenPath(name))
 }
 
 // managementCattleIo_v3 - GlobalRole
-func (c *RancherClient) ListGlobalRoles(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/globalroles")
+func (c *RancherClient) ListGlobalRoles(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, GlobalRolesPath(), opts)
 }
 
 func (c *RancherClient) GetGlobalRole(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalroles/%s", name))
+	return c.getResource(ctx, GlobalRolePath(name))
 }
 
 // managementCattleIo_v3 - GlobalRoleBinding
-func (c *RancherClient) ListGlobalRoleBindings(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/globalrolebindings")
+func (c *RancherClient) ListGlobalRoleBindings(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, GlobalRoleBindingsPath(), opts)
 }
 
 func (c *RancherClient) GetGlobalRoleBinding(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalrolebindings/%s", name))
+	return c.getResource(ctx, GlobalRoleBindingPath(name))
 }
 
 // managementCattleIo_v3 - RoleTemplate
-func (c *RancherClient) ListRoleTemplates(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/roletemplates")
+func (c *RancherClient) ListRoleTemplates(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, RoleTemplatesPath(), opts)
 }
 
 func (c *RancherClient) GetRoleTemplate(ctx context.Context, name string) (interface{}, error) {
-	return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/roletemplates/%s", name))
+	return c.getResource(ctx, RoleTemplatePath(name))
 }
 
 // managementCattleIo_v3 - ClusterRoleTemplateBinding (all namespaces)
-func (c *RancherClient) ListClusterRoleTemplateBindings(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/clusterroletemplatebindings")
+func (c *RancherClient) ListClusterRoleTemplateBindings(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ClusterRoleTemplateBindingsPath(""), opts)
 }
 
 // managementCattleIo_v3 - ClusterRoleTemplateBinding (namespaced)
-func (c *RancherClient) ListNamespacedClusterRoleTemplateBindings(ctx context.Context, namespace string) (interface{}, error) {
-	if namespace == "" {
-		return c.ListClusterRoleTemplateBindings(ctx)
-	}
-	return c.listResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings", namespace))
+func (c *RancherClient) ListNamespacedClusterRoleTemplateBindings(ctx context.Context, namespace string, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ClusterRoleTemplateBindingsPath(namespace), opts)
 }
 
 func (c *RancherClient) GetClusterRoleTemplateBinding(ctx context.Context, name, namespace string) (interface{}, error) {
-	if namespace == "" {
-		return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusterroletemplatebindings/%s", name))
-	}
-	return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings/%s", namespace, name))
+	return c.getResource(ctx, ClusterRoleTemplateBindingPath(name, namespace))
 }
 
 // managementCattleIo_v3 - ProjectRoleTemplateBinding (all namespaces)
-func (c *RancherClient) ListProjectRoleTemplateBindings(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/projectroletemplatebindings")
+func (c *RancherClient) ListProjectRoleTemplateBindings(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ProjectRoleTemplateBindingsPath(""), opts)
 }
 
 // managementCattleIo_v3 - ProjectRoleTemplateBinding (namespaced)
-func (c *RancherClient) ListNamespacedProjectRoleTemplateBindings(ctx context.Context, namespace string) (interface{}, error) {
-	if namespace == "" {
-		return c.ListProjectRoleTemplateBindings(ctx)
-	}
-	return c.listResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings", namespace))
+func (c *RancherClient) ListNamespacedProjectRoleTemplateBindings(ctx context.Context, namespace string, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ProjectRoleTemplateBindingsPath(namespace), opts)
 }
 
 func (c *RancherClient) GetProjectRoleTemplateBinding(ctx context.Context, name, namespace string) (interface{}, error) {
-	if namespace == "" {
-		return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projectroletemplatebindings/%s", name))
-	}
-	return c.getResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings/%s", namespace, name))
+	return c.getResource(ctx, ProjectRoleTemplateBindingPath(name, namespace))
 }
 
 // managementCattleIo_v3 - Project (all namespaces)
-func (c *RancherClient) ListProjectsAllNamespaces(ctx context.Context) (interface{}, error) {
-	return c.listResource(ctx, "/apis/management.cattle.io/v3/projects")
+func (c *RancherClient) ListProjectsAllNamespaces(ctx context.Context, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ProjectsPath(""), opts)
 }
 
 // managementCattleIo_v3 - Project (namespaced)
-func (c *RancherClient) ListNamespacedProjects(ctx context.Context, namespace string) (interface{}, error) {
-	if namespace == "" {
-		return c.ListProjectsAllNamespaces(ctx)
-	}
-	return c.listResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects", namespace))
+func (c *RancherClient) ListNamespacedProjects(ctx context.Context, namespace string, opts ListOptions) (interface{}, error) {
+	return c.listResource(ctx, ProjectsPath(namespace), opts)
 }
 
 // Status subresources
@@ -406,8 +379,8 @@ func (c *RancherClient) GetAuditPolicyStatus(ctx context.Context, name string) (
 }
 
 // Generic create/update helpers
-func (c *RancherClient) createResource(ctx context.Context, apiPath string, body interface{}) (interface{}, error) {
-	data, err := c.doRequest(ctx, "POST", apiPath, body)
+func (c *RancherClient) createResource(ctx context.Context, apiPath string, body interface{}, opts WriteOptions) (interface{}, error) {
+	data, err := c.doRequest(ctx, "POST", withQuery(apiPath, opts.query()), body)
 	if err != nil {
 		return nil, err
 	}
@@ -418,8 +391,8 @@ func (c *RancherClient) createResource(ctx context.Context, apiPath string, body
 	return result, nil
 }
 
-func (c *RancherClient) updateResource(ctx context.Context, apiPath string, body interface{}) (interface{}, error) {
-	data, err := c.doRequest(ctx, "PUT", apiPath, body)
+func (c *RancherClient) updateResource(ctx context.Context, apiPath string, body interface{}, opts WriteOptions) (interface{}, error) {
+	data, err := c.doRequest(ctx, "PUT", withQuery(apiPath, opts.query()), body)
 	if err != nil {
 		return nil, err
 	}
@@ -430,41 +403,18 @@ func (c *RancherClient) updateResource(ctx context.Context, apiPath string, body
 	return result, nil
 }
 
-func (c *RancherClient) patchResource(ctx context.Context, apiPath string, body interface{}) (interface{}, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, apiPath)
-
-	jsonData, err := json.Marshal(body)
+// patchResource sends a patch of the type in opts
+func (c *RancherClient) patchResource(ctx context.Context, apiPath string, body interface{}, opts PatchOptions) (interface{}, error) {
+	contentType, err := opts.Type.contentType()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	req.Header.Set("Content-Type", "application/merge-patch+json")
-	req.Header.Set("Accept", "application/json")
-
-	logrus.Debugf("Making request: PATCH %s", url)
-	resp, err := c.httpClient.Do(req)
+	data, err := c.send(ctx, "PATCH", withQuery(apiPath, opts.query()), contentType, body)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(respBody))
+		return nil, err
 	}
-
 	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 	return result, nil
@@ -473,204 +423,177 @@ func (c *RancherClient) patchResource(ctx context.Context, apiPath string, body
 // Create methods
 
 // CreateCluster creates a new cluster
-func (c *RancherClient) CreateCluster(ctx context.Context, cluster map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/clusters", cluster)
+func (c *RancherClient) CreateCluster(ctx context.Context, cluster map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, ClustersPath(), cluster, opts)
 }
 
 // CreateUser creates a new user
-func (c *RancherClient) CreateUser(ctx context.Context, user map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/users", user)
+func (c *RancherClient) CreateUser(ctx context.Context, user map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, UsersPath(), user, opts)
 }
 
 // CreateProject creates a new project
-func (c *RancherClient) CreateProject(ctx context.Context, project map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.createResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects", namespace), project)
-	}
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/projects", project)
+func (c *RancherClient) CreateProject(ctx context.Context, project map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, ProjectsPath(namespace), project, opts)
 }
 
 // CreateAuditPolicy creates a new audit policy
-func (c *RancherClient) CreateAuditPolicy(ctx context.Context, policy map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/auditlog.cattle.io/v1/auditpolicies", policy)
+func (c *RancherClient) CreateAuditPolicy(ctx context.Context, policy map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, AuditPoliciesPath(), policy, opts)
 }
 
 // CreateKubeconfig creates a new kubeconfig
-func (c *RancherClient) CreateKubeconfig(ctx context.Context, kubeconfig map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/ext.cattle.io/v1/kubeconfigs", kubeconfig)
+func (c *RancherClient) CreateKubeconfig(ctx context.Context, kubeconfig map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, KubeconfigsPath(), kubeconfig, opts)
 }
 
 // CreateToken creates a new token
-func (c *RancherClient) CreateToken(ctx context.Context, token map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/ext.cattle.io/v1/tokens", token)
+func (c *RancherClient) CreateToken(ctx context.Context, token map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, TokensPath(), token, opts)
 }
 
 // CreateGlobalRole creates a new global role
-func (c *RancherClient) CreateGlobalRole(ctx context.Context, role map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/globalroles", role)
+func (c *RancherClient) CreateGlobalRole(ctx context.Context, role map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, GlobalRolesPath(), role, opts)
 }
 
 // CreateGlobalRoleBinding creates a new global role binding
-func (c *RancherClient) CreateGlobalRoleBinding(ctx context.Context, binding map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/globalrolebindings", binding)
+func (c *RancherClient) CreateGlobalRoleBinding(ctx context.Context, binding map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, GlobalRoleBindingsPath(), binding, opts)
 }
 
 // CreateRoleTemplate creates a new role template
-func (c *RancherClient) CreateRoleTemplate(ctx context.Context, template map[string]interface{}) (interface{}, error) {
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/roletemplates", template)
+func (c *RancherClient) CreateRoleTemplate(ctx context.Context, template map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, RoleTemplatesPath(), template, opts)
 }
 
 // CreateClusterRoleTemplateBinding creates a new cluster role template binding
-func (c *RancherClient) CreateClusterRoleTemplateBinding(ctx context.Context, binding map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.createResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings", namespace), binding)
-	}
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/clusterroletemplatebindings", binding)
+func (c *RancherClient) CreateClusterRoleTemplateBinding(ctx context.Context, binding map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, ClusterRoleTemplateBindingsPath(namespace), binding, opts)
 }
 
 // CreateProjectRoleTemplateBinding creates a new project role template binding
-func (c *RancherClient) CreateProjectRoleTemplateBinding(ctx context.Context, binding map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.createResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings", namespace), binding)
-	}
-	return c.createResource(ctx, "/apis/management.cattle.io/v3/projectroletemplatebindings", binding)
+func (c *RancherClient) CreateProjectRoleTemplateBinding(ctx context.Context, binding map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.createResource(ctx, ProjectRoleTemplateBindingsPath(namespace), binding, opts)
 }
 
 // Update methods (PUT - replace)
 
 // UpdateCluster updates/replaces a cluster
-func (c *RancherClient) UpdateCluster(ctx context.Context, name string, cluster map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusters/%s", name), cluster)
+func (c *RancherClient) UpdateCluster(ctx context.Context, name string, cluster map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, ClusterPath(name), cluster, opts)
 }
 
 // UpdateUser updates/replaces a user
-func (c *RancherClient) UpdateUser(ctx context.Context, name string, user map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/users/%s", name), user)
+func (c *RancherClient) UpdateUser(ctx context.Context, name string, user map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, UserPath(name), user, opts)
 }
 
 // UpdateProject updates/replaces a project
-func (c *RancherClient) UpdateProject(ctx context.Context, name string, project map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects/%s", namespace, name), project)
-	}
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projects/%s", name), project)
+func (c *RancherClient) UpdateProject(ctx context.Context, name string, project map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, ProjectPath(name, namespace), project, opts)
 }
 
 // UpdateAuditPolicy updates/replaces an audit policy
-func (c *RancherClient) UpdateAuditPolicy(ctx context.Context, name string, policy map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/auditlog.cattle.io/v1/auditpolicies/%s", name), policy)
+func (c *RancherClient) UpdateAuditPolicy(ctx context.Context, name string, policy map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, AuditPolicyPath(name), policy, opts)
 }
 
 // UpdateKubeconfig updates/replaces a kubeconfig
-func (c *RancherClient) UpdateKubeconfig(ctx context.Context, name string, kubeconfig map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/kubeconfigs/%s", name), kubeconfig)
+func (c *RancherClient) UpdateKubeconfig(ctx context.Context, name string, kubeconfig map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, KubeconfigPath(name), kubeconfig, opts)
 }
 
 // UpdateToken updates/replaces a token
-func (c *RancherClient) UpdateToken(ctx context.Context, name string, token map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/tokens/%s", name), token)
+func (c *RancherClient) UpdateToken(ctx context.Context, name string, token map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, TokenPath(name), token, opts)
 }
 
 // UpdateGlobalRole updates/replaces a global role
-func (c *RancherClient) UpdateGlobalRole(ctx context.Context, name string, role map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalroles/%s", name), role)
+func (c *RancherClient) UpdateGlobalRole(ctx context.Context, name string, role map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, GlobalRolePath(name), role, opts)
 }
 
 // UpdateGlobalRoleBinding updates/replaces a global role binding
-func (c *RancherClient) UpdateGlobalRoleBinding(ctx context.Context, name string, binding map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalrolebindings/%s", name), binding)
+func (c *RancherClient) UpdateGlobalRoleBinding(ctx context.Context, name string, binding map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, GlobalRoleBindingPath(name), binding, opts)
 }
 
 // UpdateRoleTemplate updates/replaces a role template
-func (c *RancherClient) UpdateRoleTemplate(ctx context.Context, name string, template map[string]interface{}) (interface{}, error) {
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/roletemplates/%s", name), template)
+func (c *RancherClient) UpdateRoleTemplate(ctx context.Context, name string, template map[string]interface{}, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, RoleTemplatePath(name), template, opts)
 }
 
 // UpdateClusterRoleTemplateBinding updates/replaces a cluster role template binding
-func (c *RancherClient) UpdateClusterRoleTemplateBinding(ctx context.Context, name string, binding map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings/%s", namespace, name), binding)
-	}
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusterroletemplatebindings/%s", name), binding)
+func (c *RancherClient) UpdateClusterRoleTemplateBinding(ctx context.Context, name string, binding map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, ClusterRoleTemplateBindingPath(name, namespace), binding, opts)
 }
 
 // UpdateProjectRoleTemplateBinding updates/replaces a project role template binding
-func (c *RancherClient) UpdateProjectRoleTemplateBinding(ctx context.Context, name string, binding map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings/%s", namespace, name), binding)
-	}
-	return c.updateResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projectroletemplatebindings/%s", name), binding)
+func (c *RancherClient) UpdateProjectRoleTemplateBinding(ctx context.Context, name string, binding map[string]interface{}, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.updateResource(ctx, ProjectRoleTemplateBindingPath(name, namespace), binding, opts)
 }
 
 // Patch methods (PATCH - partial update)
 
 // PatchCluster partially updates a cluster
-func (c *RancherClient) PatchCluster(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusters/%s", name), patch)
+func (c *RancherClient) PatchCluster(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, ClusterPath(name), patch, opts)
 }
 
 // PatchUser partially updates a user
-func (c *RancherClient) PatchUser(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/users/%s", name), patch)
+func (c *RancherClient) PatchUser(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, UserPath(name), patch, opts)
 }
 
 // PatchProject partially updates a project
-func (c *RancherClient) PatchProject(ctx context.Context, name string, patch map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects/%s", namespace, name), patch)
-	}
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projects/%s", name), patch)
+func (c *RancherClient) PatchProject(ctx context.Context, name string, patch interface{}, namespace string, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, ProjectPath(name, namespace), patch, opts)
 }
 
 // PatchAuditPolicy partially updates an audit policy
-func (c *RancherClient) PatchAuditPolicy(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/auditlog.cattle.io/v1/auditpolicies/%s", name), patch)
+func (c *RancherClient) PatchAuditPolicy(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, AuditPolicyPath(name), patch, opts)
 }
 
 // PatchKubeconfig partially updates a kubeconfig
-func (c *RancherClient) PatchKubeconfig(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/kubeconfigs/%s", name), patch)
+func (c *RancherClient) PatchKubeconfig(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, KubeconfigPath(name), patch, opts)
 }
 
 // PatchToken partially updates a token
-func (c *RancherClient) PatchToken(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/tokens/%s", name), patch)
+func (c *RancherClient) PatchToken(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, TokenPath(name), patch, opts)
 }
 
 // PatchGlobalRole partially updates a global role
-func (c *RancherClient) PatchGlobalRole(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalroles/%s", name), patch)
+func (c *RancherClient) PatchGlobalRole(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, GlobalRolePath(name), patch, opts)
 }
 
 // PatchGlobalRoleBinding partially updates a global role binding
-func (c *RancherClient) PatchGlobalRoleBinding(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalrolebindings/%s", name), patch)
+func (c *RancherClient) PatchGlobalRoleBinding(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, GlobalRoleBindingPath(name), patch, opts)
 }
 
 // PatchRoleTemplate partially updates a role template
-func (c *RancherClient) PatchRoleTemplate(ctx context.Context, name string, patch map[string]interface{}) (interface{}, error) {
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/roletemplates/%s", name), patch)
+func (c *RancherClient) PatchRoleTemplate(ctx context.Context, name string, patch interface{}, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, RoleTemplatePath(name), patch, opts)
 }
 
 // PatchClusterRoleTemplateBinding partially updates a cluster role template binding
-func (c *RancherClient) PatchClusterRoleTemplateBinding(ctx context.Context, name string, patch map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings/%s", namespace, name), patch)
-	}
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusterroletemplatebindings/%s", name), patch)
+func (c *RancherClient) PatchClusterRoleTemplateBinding(ctx context.Context, name string, patch interface{}, namespace string, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, ClusterRoleTemplateBindingPath(name, namespace), patch, opts)
 }
 
 // PatchProjectRoleTemplateBinding partially updates a project role template binding
-func (c *RancherClient) PatchProjectRoleTemplateBinding(ctx context.Context, name string, patch map[string]interface{}, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings/%s", namespace, name), patch)
-	}
-	return c.patchResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projectroletemplatebindings/%s", name), patch)
+func (c *RancherClient) PatchProjectRoleTemplateBinding(ctx context.Context, name string, patch interface{}, namespace string, opts PatchOptions) (interface{}, error) {
+	return c.patchResource(ctx, ProjectRoleTemplateBindingPath(name, namespace), patch, opts)
 }
 
 // Generic delete helper
-func (c *RancherClient) deleteResource(ctx context.Context, apiPath string) (interface{}, error) {
-	data, err := c.doRequest(ctx, "DELETE", apiPath, nil)
+func (c *RancherClient) deleteResource(ctx context.Context, apiPath string, opts WriteOptions) (interface{}, error) {
+	data, err := c.doRequest(ctx, "DELETE", withQuery(apiPath, opts.query()), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -688,65 +611,56 @@ func (c *RancherClient) deleteResource(ctx context.Context, apiPath string) (int
 // Delete methods
 
 // DeleteCluster deletes a cluster
-func (c *RancherClient) DeleteCluster(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusters/%s", name))
+func (c *RancherClient) DeleteCluster(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, ClusterPath(name), opts)
 }
 
 // DeleteUser deletes a user
-func (c *RancherClient) DeleteUser(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/users/%s", name))
+func (c *RancherClient) DeleteUser(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, UserPath(name), opts)
 }
 
 // DeleteProject deletes a project
-func (c *RancherClient) DeleteProject(ctx context.Context, name string, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projects/%s", namespace, name))
-	}
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projects/%s", name))
+func (c *RancherClient) DeleteProject(ctx context.Context, name string, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, ProjectPath(name, namespace), opts)
 }
 
 // DeleteAuditPolicy deletes an audit policy
-func (c *RancherClient) DeleteAuditPolicy(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/auditlog.cattle.io/v1/auditpolicies/%s", name))
+func (c *RancherClient) DeleteAuditPolicy(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, AuditPolicyPath(name), opts)
 }
 
 // DeleteKubeconfig deletes a kubeconfig
-func (c *RancherClient) DeleteKubeconfig(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/kubeconfigs/%s", name))
+func (c *RancherClient) DeleteKubeconfig(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, KubeconfigPath(name), opts)
 }
 
 // DeleteToken deletes a token
-func (c *RancherClient) DeleteToken(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/ext.cattle.io/v1/tokens/%s", name))
+func (c *RancherClient) DeleteToken(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, TokenPath(name), opts)
 }
 
 // DeleteGlobalRole deletes a global role
-func (c *RancherClient) DeleteGlobalRole(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalroles/%s", name))
+func (c *RancherClient) DeleteGlobalRole(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, GlobalRolePath(name), opts)
 }
 
 // DeleteGlobalRoleBinding deletes a global role binding
-func (c *RancherClient) DeleteGlobalRoleBinding(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/globalrolebindings/%s", name))
+func (c *RancherClient) DeleteGlobalRoleBinding(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, GlobalRoleBindingPath(name), opts)
 }
 
 // DeleteRoleTemplate deletes a role template
-func (c *RancherClient) DeleteRoleTemplate(ctx context.Context, name string) (interface{}, error) {
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/roletemplates/%s", name))
+func (c *RancherClient) DeleteRoleTemplate(ctx context.Context, name string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, RoleTemplatePath(name), opts)
 }
 
 // DeleteClusterRoleTemplateBinding deletes a cluster role template binding
-func (c *RancherClient) DeleteClusterRoleTemplateBinding(ctx context.Context, name string, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/clusterroletemplatebindings/%s", namespace, name))
-	}
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/clusterroletemplatebindings/%s", name))
+func (c *RancherClient) DeleteClusterRoleTemplateBinding(ctx context.Context, name string, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, ClusterRoleTemplateBindingPath(name, namespace), opts)
 }
 
 // DeleteProjectRoleTemplateBinding deletes a project role template binding
-func (c *RancherClient) DeleteProjectRoleTemplateBinding(ctx context.Context, name string, namespace string) (interface{}, error) {
-	if namespace != "" {
-		return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/namespaces/%s/projectroletemplatebindings/%s", namespace, name))
-	}
-	return c.deleteResource(ctx, fmt.Sprintf("/apis/management.cattle.io/v3/projectroletemplatebindings/%s", name))
+func (c *RancherClient) DeleteProjectRoleTemplateBinding(ctx context.Context, name string, namespace string, opts WriteOptions) (interface{}, error) {
+	return c.deleteResource(ctx, ProjectRoleTemplateBindingPath(name, namespace), opts)
 }
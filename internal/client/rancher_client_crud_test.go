@@ -55,7 +55,7 @@ func testGlobalRoleCRUD(ctx context.Context, t *testing.T, cleanupItems *[]clean
 			"rules":       []interface{}{},
 		}
 
-		result, err := testClient.CreateGlobalRole(ctx, globalRole)
+		result, err := testClient.CreateGlobalRole(ctx, globalRole, WriteOptions{})
 		if err != nil {
 			t.Fatalf("CreateGlobalRole failed: %v", err)
 		}
@@ -67,7 +67,7 @@ func testGlobalRoleCRUD(ctx context.Context, t *testing.T, cleanupItems *[]clean
 			resourceType: "globalrole",
 			name:         testName,
 			cleanup: func(ctx context.Context, t *testing.T) {
-				_, err := testClient.DeleteGlobalRole(ctx, testName)
+				_, err := testClient.DeleteGlobalRole(ctx, testName, WriteOptions{})
 				if err != nil {
 					t.Logf("Cleanup: DeleteGlobalRole failed: %v", err)
 				}
@@ -110,7 +110,7 @@ func testGlobalRoleCRUD(ctx context.Context, t *testing.T, cleanupItems *[]clean
 		// Update with new displayName
 		existingObj["displayName"] = "MCP Test Global Role - Updated"
 
-		result, err := testClient.UpdateGlobalRole(ctx, testName, existingObj)
+		result, err := testClient.UpdateGlobalRole(ctx, testName, existingObj, WriteOptions{})
 		if err != nil {
 			t.Fatalf("UpdateGlobalRole failed: %v", err)
 		}
@@ -126,7 +126,7 @@ func testGlobalRoleCRUD(ctx context.Context, t *testing.T, cleanupItems *[]clean
 			"displayName": "MCP Test Global Role - Patched",
 		}
 
-		result, err := testClient.PatchGlobalRole(ctx, testName, patch)
+		result, err := testClient.PatchGlobalRole(ctx, testName, patch, PatchOptions{})
 		if err != nil {
 			t.Fatalf("PatchGlobalRole failed: %v", err)
 		}
@@ -164,7 +164,7 @@ func testRoleTemplateCRUD(ctx context.Context, t *testing.T, cleanupItems *[]cle
 			"rules":       []interface{}{},
 		}
 
-		result, err := testClient.CreateRoleTemplate(ctx, roleTemplate)
+		result, err := testClient.CreateRoleTemplate(ctx, roleTemplate, WriteOptions{})
 		if err != nil {
 			t.Fatalf("CreateRoleTemplate failed: %v", err)
 		}
@@ -176,7 +176,7 @@ func testRoleTemplateCRUD(ctx context.Context, t *testing.T, cleanupItems *[]cle
 			resourceType: "roletemplate",
 			name:         testName,
 			cleanup: func(ctx context.Context, t *testing.T) {
-				_, err := testClient.DeleteRoleTemplate(ctx, testName)
+				_, err := testClient.DeleteRoleTemplate(ctx, testName, WriteOptions{})
 				if err != nil {
 					t.Logf("Cleanup: DeleteRoleTemplate failed: %v", err)
 				}
@@ -219,7 +219,7 @@ func testRoleTemplateCRUD(ctx context.Context, t *testing.T, cleanupItems *[]cle
 		// Update with new displayName
 		existingObj["displayName"] = "MCP Test Role Template - Updated"
 
-		result, err := testClient.UpdateRoleTemplate(ctx, testName, existingObj)
+		result, err := testClient.UpdateRoleTemplate(ctx, testName, existingObj, WriteOptions{})
 		if err != nil {
 			t.Fatalf("UpdateRoleTemplate failed: %v", err)
 		}
@@ -235,7 +235,7 @@ func testRoleTemplateCRUD(ctx context.Context, t *testing.T, cleanupItems *[]cle
 			"displayName": "MCP Test Role Template - Patched",
 		}
 
-		result, err := testClient.PatchRoleTemplate(ctx, testName, patch)
+		result, err := testClient.PatchRoleTemplate(ctx, testName, patch, PatchOptions{})
 		if err != nil {
 			t.Fatalf("PatchRoleTemplate failed: %v", err)
 		}
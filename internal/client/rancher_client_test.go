@@ -20,8 +20,9 @@ func TestMain(m *testing.M) {
 	insecureSkipVerify := os.Getenv("RANCHER_INSECURE_SKIP_VERIFY") == "true" || os.Getenv("RANCHER_INSECURE_SKIP_VERIFY") == "1"
 
 	if rancherURL == "" || rancherToken == "" {
+		// Unit tests that use an httptest server still run
 		fmt.Println("Skipping integration tests: RANCHER_URL and RANCHER_TOKEN must be set")
-		os.Exit(0)
+		os.Exit(m.Run())
 	}
 
 	testClient = NewRancherClient(rancherURL, rancherToken, insecureSkipVerify)
@@ -38,9 +39,19 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// requireTestClient skips integration tests when no Rancher server is
+// configured
+func requireTestClient(t *testing.T) {
+	t.Helper()
+	if testClient == nil {
+		t.Skip("RANCHER_URL and RANCHER_TOKEN are not set")
+	}
+}
+
 func TestListClusters(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListClusters(ctx)
+	result, err := testClient.ListClusters(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListClusters failed: %v", err)
 	}
@@ -51,8 +62,9 @@ func TestListClusters(t *testing.T) {
 }
 
 func TestGetCluster(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	clusters, err := testClient.ListClusters(ctx)
+	clusters, err := testClient.ListClusters(ctx, ListOptions{})
 	if err != nil {
 		t.Skipf("Skipping GetCluster: ListClusters failed: %v", err)
 	}
@@ -73,8 +85,9 @@ func TestGetCluster(t *testing.T) {
 }
 
 func TestGetClusterStatus(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	clusters, err := testClient.ListClusters(ctx)
+	clusters, err := testClient.ListClusters(ctx, ListOptions{})
 	if err != nil {
 		t.Skipf("Skipping GetClusterStatus: ListClusters failed: %v", err)
 	}
@@ -95,8 +108,9 @@ func TestGetClusterStatus(t *testing.T) {
 }
 
 func TestListUsers(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListUsers(ctx)
+	result, err := testClient.ListUsers(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListUsers failed: %v", err)
 	}
@@ -107,8 +121,9 @@ func TestListUsers(t *testing.T) {
 }
 
 func TestGetUser(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	users, err := testClient.ListUsers(ctx)
+	users, err := testClient.ListUsers(ctx, ListOptions{})
 	if err != nil {
 		t.Skipf("Skipping GetUser: ListUsers failed: %v", err)
 	}
@@ -129,8 +144,9 @@ func TestGetUser(t *testing.T) {
 }
 
 func TestGetUserStatus(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	users, err := testClient.ListUsers(ctx)
+	users, err := testClient.ListUsers(ctx, ListOptions{})
 	if err != nil {
 		t.Skipf("Skipping GetUserStatus: ListUsers failed: %v", err)
 	}
@@ -151,8 +167,9 @@ func TestGetUserStatus(t *testing.T) {
 }
 
 func TestListProjects(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListProjects(ctx)
+	result, err := testClient.ListProjects(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListProjects failed: %v", err)
 	}
@@ -163,8 +180,9 @@ func TestListProjects(t *testing.T) {
 }
 
 func TestListRoleTemplates(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListRoleTemplates(ctx)
+	result, err := testClient.ListRoleTemplates(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListRoleTemplates failed: %v", err)
 	}
@@ -175,8 +193,9 @@ func TestListRoleTemplates(t *testing.T) {
 }
 
 func TestListGlobalRoles(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListGlobalRoles(ctx)
+	result, err := testClient.ListGlobalRoles(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListGlobalRoles failed: %v", err)
 	}
@@ -187,8 +206,9 @@ func TestListGlobalRoles(t *testing.T) {
 }
 
 func TestListGlobalRoleBindings(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListGlobalRoleBindings(ctx)
+	result, err := testClient.ListGlobalRoleBindings(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListGlobalRoleBindings failed: %v", err)
 	}
@@ -199,8 +219,9 @@ func TestListGlobalRoleBindings(t *testing.T) {
 }
 
 func TestListClusterRoleTemplateBindings(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListClusterRoleTemplateBindings(ctx)
+	result, err := testClient.ListClusterRoleTemplateBindings(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListClusterRoleTemplateBindings failed: %v", err)
 	}
@@ -211,8 +232,9 @@ func TestListClusterRoleTemplateBindings(t *testing.T) {
 }
 
 func TestListProjectRoleTemplateBindings(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListProjectRoleTemplateBindings(ctx)
+	result, err := testClient.ListProjectRoleTemplateBindings(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListProjectRoleTemplateBindings failed: %v", err)
 	}
@@ -223,8 +245,9 @@ func TestListProjectRoleTemplateBindings(t *testing.T) {
 }
 
 func TestListTokens(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListTokens(ctx)
+	result, err := testClient.ListTokens(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListTokens failed: %v", err)
 	}
@@ -235,8 +258,9 @@ func TestListTokens(t *testing.T) {
 }
 
 func TestListKubeconfigs(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListKubeconfigs(ctx)
+	result, err := testClient.ListKubeconfigs(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListKubeconfigs failed: %v", err)
 	}
@@ -247,8 +271,9 @@ func TestListKubeconfigs(t *testing.T) {
 }
 
 func TestListAuditPolicies(t *testing.T) {
+	requireTestClient(t)
 	ctx := context.Background()
-	result, err := testClient.ListAuditPolicies(ctx)
+	result, err := testClient.ListAuditPolicies(ctx, ListOptions{})
 	if err != nil {
 		t.Fatalf("ListAuditPolicies failed: %v", err)
 	}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only failures that
+// are safe to repeat are retried:
+//   - 429 responses, which the server rejected without acting on them
+//   - requests that never reached the server because the connection failed
+//   - 502, 503 and 504 responses and dropped connections, for idempotent
+//     requests only. GET, PUT, DELETE, and merge and apply PATCH requests
+//     are idempotent, and so is a POST that names the object it creates,
+//     because repeating it fails with AlreadyExists instead of creating a
+//     duplicate. JSON patches are not retried.
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is retried after the first
+	// attempt. Zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry. It doubles on each
+	// later retry up to MaxBackoff, and a random part of it is skipped so that
+	// clients do not retry in lockstep.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxRetryAfter caps how long the client waits when the server sends
+	// Retry-After. Requests asked to wait longer fail instead.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy is used by clients from NewRancherClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	MaxRetryAfter:  30 * time.Second,
+}
+
+// SetRetryPolicy replaces the retry policy of c. Clients later derived from
+// c with WithToken share it.
+func (c *RancherClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// retryDelay decides whether the failed attempt number attempt (from 1) may
+// be retried, and how long to wait first
+func (p RetryPolicy) retryDelay(attempt int, idempotent bool, err error) (time.Duration, bool) {
+	if attempt > p.MaxRetries || !retryable(err, idempotent) {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if retryAfter := time.Duration(apiErr.RetryAfterSeconds()) * time.Second; retryAfter > 0 {
+			if retryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+		}
+	}
+	return delay, true
+}
+
+// backoff returns the jittered exponential backoff before retry number
+// attempt (from 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Wait between half and all of the backoff
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func retryable(err error, idempotent bool) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return true
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return idempotent
+		default:
+			return false
+		}
+	}
+
+	// Errors that are not from the transport, such as a request that could
+	// not be built, fail the same way every time
+	var transportErr *transportError
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+
+	// A failed dial means the request was never sent
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return idempotent
+}
+
+// transportError is a failure to get a response at all, as returned by
+// http.Client.Do
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return fmt.Sprintf("request failed: %v", e.err)
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isIdempotent reports whether repeating a request cannot change the outcome
+// beyond what a single successful attempt would have done
+func isIdempotent(method, contentType string, body interface{}) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPatch:
+		// A JSON patch that appends to or removes from an array would be
+		// applied twice
+		return contentType != jsonPatchContentType
+	case http.MethodPost:
+		obj, _ := body.(map[string]interface{})
+		metadata, _ := obj["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		return name != ""
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date
+func parseRetryAfter(value string) int {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return seconds
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return int(wait.Round(time.Second) / time.Second)
+		}
+	}
+	return 0
+}
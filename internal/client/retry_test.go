@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy keeps backoffs short so that the tests run quickly
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	MaxRetryAfter:  2 * time.Second,
+}
+
+// failingServer answers the first failures requests with fail and later ones
+// with an empty object. It returns the number of requests received.
+func failingServer(t *testing.T, failures int32, fail http.HandlerFunc) (*RancherClient, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			fail(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"metadata":{"name":"test"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewRancherClient(server.URL, "token", false)
+	c.SetRetryPolicy(testRetryPolicy)
+	return c, &requests
+}
+
+func respondWith(code int, retryAfter string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(code)
+		w.Write([]byte(http.StatusText(code)))
+	}
+}
+
+// dropConnection closes the connection without sending a response
+func dropConnection(w http.ResponseWriter, r *http.Request) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err == nil {
+		conn.Close()
+	}
+}
+
+func namedObject() map[string]interface{} {
+	return map[string]interface{}{"metadata": map[string]interface{}{"name": "test"}}
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32
+		fail     http.HandlerFunc
+		call     func(c *RancherClient) (interface{}, error)
+		wantErr  bool
+		requests int32
+	}{
+		{
+			name:     "GET is retried on 503",
+			failures: 2,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call:     func(c *RancherClient) (interface{}, error) { return c.getResource(context.Background(), "/v1/test") },
+			requests: 3,
+		},
+		{
+			name:     "GET is retried on a dropped connection",
+			failures: 1,
+			fail:     dropConnection,
+			call:     func(c *RancherClient) (interface{}, error) { return c.getResource(context.Background(), "/v1/test") },
+			requests: 2,
+		},
+		{
+			name:     "GET gives up after MaxRetries",
+			failures: 10,
+			fail:     respondWith(http.StatusBadGateway, ""),
+			call:     func(c *RancherClient) (interface{}, error) { return c.getResource(context.Background(), "/v1/test") },
+			wantErr:  true,
+			requests: 4,
+		},
+		{
+			name:     "PUT is retried on 504",
+			failures: 1,
+			fail:     respondWith(http.StatusGatewayTimeout, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.updateResource(context.Background(), "/v1/test/test", namedObject(), WriteOptions{})
+			},
+			requests: 2,
+		},
+		{
+			name:     "DELETE is retried on 503",
+			failures: 1,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.deleteResource(context.Background(), "/v1/test/test", WriteOptions{})
+			},
+			requests: 2,
+		},
+		{
+			name:     "POST with a name is retried on 503",
+			failures: 1,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.createResource(context.Background(), "/v1/test", namedObject(), WriteOptions{})
+			},
+			requests: 2,
+		},
+		{
+			name:     "POST with generateName is not retried on 503",
+			failures: 1,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				body := map[string]interface{}{"metadata": map[string]interface{}{"generateName": "test-"}}
+				return c.createResource(context.Background(), "/v1/test", body, WriteOptions{})
+			},
+			wantErr:  true,
+			requests: 1,
+		},
+		{
+			name:     "POST with generateName is not retried on a dropped connection",
+			failures: 1,
+			fail:     dropConnection,
+			call: func(c *RancherClient) (interface{}, error) {
+				body := map[string]interface{}{"metadata": map[string]interface{}{"generateName": "test-"}}
+				return c.createResource(context.Background(), "/v1/test", body, WriteOptions{})
+			},
+			wantErr:  true,
+			requests: 1,
+		},
+		{
+			name:     "POST with generateName is retried on 429",
+			failures: 1,
+			fail:     respondWith(http.StatusTooManyRequests, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				body := map[string]interface{}{"metadata": map[string]interface{}{"generateName": "test-"}}
+				return c.createResource(context.Background(), "/v1/test", body, WriteOptions{})
+			},
+			requests: 2,
+		},
+		{
+			name:     "merge PATCH is retried on 503",
+			failures: 1,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.PatchGlobalRole(context.Background(), "admin", map[string]interface{}{"description": "x"}, PatchOptions{})
+			},
+			requests: 2,
+		},
+		{
+			name:     "JSON PATCH is not retried on 503",
+			failures: 1,
+			fail:     respondWith(http.StatusServiceUnavailable, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				ops := []interface{}{map[string]interface{}{"op": "add", "path": "/rules/-", "value": map[string]interface{}{}}}
+				return c.PatchGlobalRole(context.Background(), "admin", ops, PatchOptions{Type: JSONPatch})
+			},
+			wantErr:  true,
+			requests: 1,
+		},
+		{
+			name:     "403 is not retried",
+			failures: 1,
+			fail:     respondWith(http.StatusForbidden, ""),
+			call:     func(c *RancherClient) (interface{}, error) { return c.getResource(context.Background(), "/v1/test") },
+			wantErr:  true,
+			requests: 1,
+		},
+		{
+			name:     "409 is not retried",
+			failures: 1,
+			fail:     respondWith(http.StatusConflict, ""),
+			call: func(c *RancherClient) (interface{}, error) {
+				return c.updateResource(context.Background(), "/v1/test/test", namedObject(), WriteOptions{})
+			},
+			wantErr:  true,
+			requests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, requests := failingServer(t, tt.failures, tt.fail)
+			_, err := tt.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.requests {
+				t.Errorf("server received %d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	c, requests := failingServer(t, 1, respondWith(http.StatusTooManyRequests, "1"))
+
+	start := time.Now()
+	if _, err := c.getResource(context.Background(), "/v1/test"); err != nil {
+		t.Fatalf("getResource failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s from Retry-After", elapsed)
+	}
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestRetryAfterAboveLimitFails(t *testing.T) {
+	c, requests := failingServer(t, 1, respondWith(http.StatusTooManyRequests, "60"))
+
+	_, err := c.getResource(context.Background(), "/v1/test")
+	if !IsTooManyRequests(err) {
+		t.Fatalf("err = %v, want TooManyRequests", err)
+	}
+	if got := err.(*APIError).RetryAfterSeconds(); got != 60 {
+		t.Errorf("RetryAfterSeconds() = %d, want 60", got)
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	c, requests := failingServer(t, 1, respondWith(http.StatusServiceUnavailable, ""))
+	c.SetRetryPolicy(RetryPolicy{})
+
+	if _, err := c.getResource(context.Background(), "/v1/test"); err == nil {
+		t.Fatal("getResource succeeded, want the 503")
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestRetrySkipsRequestErrors(t *testing.T) {
+	c, requests := failingServer(t, 0, nil)
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute})
+
+	// A request that cannot be built fails the same way on every attempt,
+	// so it returns at once instead of waiting out the backoff
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := c.getResource(ctx, "/v1/test\x7f")
+	if err == nil || !strings.Contains(err.Error(), "failed to create request") {
+		t.Fatalf("err = %v, want the request to fail to build", err)
+	}
+	if got := atomic.LoadInt32(requests); got != 0 {
+		t.Errorf("server received %d requests, want 0", got)
+	}
+}
+
+func TestRetryStopsWhenContextIsCanceled(t *testing.T) {
+	c, requests := failingServer(t, 10, respondWith(http.StatusServiceUnavailable, ""))
+	c.SetRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.getResource(ctx, "/v1/test"); err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("getResource returned after %v, want it to stop waiting when ctx is done", elapsed)
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{50, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := p.backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", 0},
+		{"5", 5},
+		{"0", 0},
+		{"-3", 0},
+		{"soon", 0},
+		{time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 10},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		got := parseRetryAfter(tt.value)
+		// An HTTP date has second precision, so allow for a tick of the clock
+		if got != tt.want && got != tt.want-1 {
+			t.Errorf("parseRetryAfter(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
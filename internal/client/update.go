@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxConflictRetries is how many times UpdateWithRetry reads the object
+// again after its write lost a race with another writer
+const maxConflictRetries = 5
+
+// UpdateWithRetry reads the object at apiPath, lets mutate change it and
+// writes it back. The write carries the resourceVersion that was read, so it
+// fails with a Conflict if the object changed in between, for example because
+// a Rancher controller updated its status; the object is then read again and
+// mutate reapplied to the new version. An error from mutate stops the update.
+// The write is sent with opts; the reads are not.
+func (c *RancherClient) UpdateWithRetry(ctx context.Context, apiPath string, opts WriteOptions, mutate func(obj map[string]interface{}) error) (interface{}, error) {
+	for attempt := 1; ; attempt++ {
+		current, err := c.getResource(ctx, apiPath)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response reading %s", apiPath)
+		}
+		if err := mutate(obj); err != nil {
+			return nil, err
+		}
+
+		result, err := c.updateResource(ctx, apiPath, obj, opts)
+		if err == nil || !IsConflict(err) || attempt > maxConflictRetries {
+			return result, err
+		}
+		logrus.WithContext(ctx).Debugf("Update of %s conflicted, retrying against the latest version (retry %d of %d)", apiPath, attempt, maxConflictRetries)
+		if !sleepContext(ctx, c.retryPolicy.backoff(attempt)) {
+			return nil, ctx.Err()
+		}
+	}
+}
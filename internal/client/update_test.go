@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// objectServer stores a single object with optimistic concurrency, like the
+// Kubernetes API. Before each of the first races writes it updates the
+// object itself, as a controller would, so that the write conflicts.
+type objectServer struct {
+	mu    sync.Mutex
+	obj   map[string]interface{}
+	races int
+	gets  int
+	puts  int
+}
+
+func newObjectServer(t *testing.T, races int) (*objectServer, *RancherClient) {
+	t.Helper()
+	s := &objectServer{
+		obj: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "u-1", "resourceVersion": "1"},
+			"enabled":  true,
+		},
+		races: races,
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	c := NewRancherClient(server.URL, "token", false)
+	c.SetRetryPolicy(testRetryPolicy)
+	return s, c
+}
+
+func (s *objectServer) resourceVersion() string {
+	return s.obj["metadata"].(map[string]interface{})["resourceVersion"].(string)
+}
+
+func (s *objectServer) bump() {
+	rv, _ := strconv.Atoi(s.resourceVersion())
+	s.obj["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(rv + 1)
+}
+
+func (s *objectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		s.gets++
+	case http.MethodPut:
+		s.puts++
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if s.races > 0 {
+			s.races--
+			s.obj["status"] = map[string]interface{}{"synced": s.puts}
+			s.bump()
+		}
+		rv, _ := body["metadata"].(map[string]interface{})["resourceVersion"].(string)
+		if rv != s.resourceVersion() {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"kind":"Status","reason":"Conflict","message":"the object has been modified; please apply your changes to the latest version and try again"}`))
+			return
+		}
+		s.obj = body
+		s.bump()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	json.NewEncoder(w).Encode(s.obj)
+}
+
+func disable(obj map[string]interface{}) error {
+	obj["enabled"] = false
+	return nil
+}
+
+func TestUpdateWithRetry(t *testing.T) {
+	s, c := newObjectServer(t, 0)
+
+	result, err := c.UpdateWithRetry(context.Background(), UserPath("u-1"), WriteOptions{}, disable)
+	if err != nil {
+		t.Fatalf("UpdateWithRetry failed: %v", err)
+	}
+	if enabled := result.(map[string]interface{})["enabled"]; enabled != false {
+		t.Errorf("enabled = %v, want false", enabled)
+	}
+	if s.gets != 1 || s.puts != 1 {
+		t.Errorf("server received %d GETs and %d PUTs, want 1 of each", s.gets, s.puts)
+	}
+}
+
+func TestUpdateWithRetryRereadsOnConflict(t *testing.T) {
+	s, c := newObjectServer(t, 2)
+
+	if _, err := c.UpdateWithRetry(context.Background(), UserPath("u-1"), WriteOptions{}, disable); err != nil {
+		t.Fatalf("UpdateWithRetry failed: %v", err)
+	}
+	if s.gets != 3 || s.puts != 3 {
+		t.Errorf("server received %d GETs and %d PUTs, want 3 of each", s.gets, s.puts)
+	}
+	// Both the concurrent change and the mutation survive
+	if s.obj["enabled"] != false || s.obj["status"] == nil {
+		t.Errorf("stored object = %v, want enabled false and the concurrent status", s.obj)
+	}
+}
+
+func TestUpdateWithRetryGivesUp(t *testing.T) {
+	s, c := newObjectServer(t, 100)
+
+	_, err := c.UpdateWithRetry(context.Background(), UserPath("u-1"), WriteOptions{}, disable)
+	if !IsConflict(err) {
+		t.Fatalf("err = %v, want a Conflict", err)
+	}
+	if s.puts != maxConflictRetries+1 {
+		t.Errorf("server received %d PUTs, want %d", s.puts, maxConflictRetries+1)
+	}
+}
+
+func TestUpdateWithRetryMutateError(t *testing.T) {
+	s, c := newObjectServer(t, 0)
+	errRefused := errors.New("refused")
+
+	_, err := c.UpdateWithRetry(context.Background(), UserPath("u-1"), WriteOptions{}, func(map[string]interface{}) error {
+		return errRefused
+	})
+	if !errors.Is(err, errRefused) {
+		t.Fatalf("err = %v, want %v", err, errRefused)
+	}
+	if s.puts != 0 {
+		t.Errorf("server received %d PUTs, want none", s.puts)
+	}
+}
+
+func TestUpdateWithRetryDryRun(t *testing.T) {
+	var putQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			putQuery = r.URL.RawQuery
+		} else if r.URL.RawQuery != "" {
+			t.Errorf("GET has query %q, want none", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"metadata":{"name":"u-1","resourceVersion":"1"}}`))
+	}))
+	defer server.Close()
+	c := NewRancherClient(server.URL, "token", false)
+
+	if _, err := c.UpdateWithRetry(context.Background(), UserPath("u-1"), WriteOptions{DryRun: true}, disable); err != nil {
+		t.Fatalf("UpdateWithRetry failed: %v", err)
+	}
+	if putQuery != "dryRun=All" {
+		t.Errorf("PUT query = %q, want dryRun=All", putQuery)
+	}
+}
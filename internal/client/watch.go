@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WatchEvent is a single event from a Kubernetes watch stream
+type WatchEvent struct {
+	Type   string                 `json:"type"`
+	Object map[string]interface{} `json:"object"`
+}
+
+// WatchOptions narrows a watch to a subset of a collection
+type WatchOptions struct {
+	FieldSelector string
+	LabelSelector string
+}
+
+// fieldSelectorEscaper escapes the characters that separate terms and
+// operators in a field selector, as Kubernetes expects in values
+var fieldSelectorEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, `=`, `\=`)
+
+// NameFieldSelector is a field selector matching only the object called name
+func NameFieldSelector(name string) string {
+	return "metadata.name=" + fieldSelectorEscaper.Replace(name)
+}
+
+const (
+	watchTimeoutSeconds = 300
+	watchMinBackoff     = time.Second
+	watchMaxBackoff     = 30 * time.Second
+)
+
+// Watch streams changes to the collection at apiPath and calls handler for
+// every ADDED, MODIFIED or DELETED event. Only changes made after Watch is
+// called are reported. Dropped streams are resumed from the last seen
+// resourceVersion, so Watch only returns once ctx is cancelled.
+func (c *RancherClient) Watch(ctx context.Context, apiPath string, opts WatchOptions, handler func(WatchEvent)) error {
+	backoff := watchMinBackoff
+	resourceVersion := ""
+
+	for {
+		if resourceVersion == "" {
+			rv, err := c.currentResourceVersion(ctx, apiPath, opts)
+			if err != nil {
+				logrus.WithContext(ctx).Debugf("Watch %s: failed to list: %v", apiPath, err)
+				if !sleepContext(ctx, backoff) {
+					return ctx.Err()
+				}
+				backoff = nextWatchBackoff(backoff)
+				continue
+			}
+			resourceVersion = rv
+		}
+
+		rv, err := c.watchOnce(ctx, apiPath, opts, resourceVersion, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == errWatchExpired {
+			// The resourceVersion is too old to resume from; relist and
+			// report a change since events may have been missed
+			logrus.WithContext(ctx).Debugf("Watch %s: resourceVersion %s expired", apiPath, resourceVersion)
+			resourceVersion = ""
+			handler(WatchEvent{Type: "MODIFIED"})
+			continue
+		}
+		if err != nil {
+			logrus.WithContext(ctx).Debugf("Watch %s: %v", apiPath, err)
+			if !sleepContext(ctx, backoff) {
+				return ctx.Err()
+			}
+			backoff = nextWatchBackoff(backoff)
+		} else {
+			backoff = watchMinBackoff
+		}
+		if rv != "" {
+			resourceVersion = rv
+		}
+	}
+}
+
+var errWatchExpired = errors.New("watch resourceVersion expired")
+
+// currentResourceVersion lists the collection to find where a watch should start
+func (c *RancherClient) currentResourceVersion(ctx context.Context, apiPath string, opts WatchOptions) (string, error) {
+	query := watchQuery(opts)
+	query.Set("limit", "1")
+
+	data, err := c.doRequest(ctx, "GET", apiPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var list struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return list.Metadata.ResourceVersion, nil
+}
+
+// watchOnce consumes a single watch stream and returns the last resourceVersion seen
+func (c *RancherClient) watchOnce(ctx context.Context, apiPath string, opts WatchOptions, resourceVersion string, handler func(WatchEvent)) (string, error) {
+	query := watchQuery(opts)
+	query.Set("watch", "true")
+	query.Set("allowWatchBookmarks", "true")
+	query.Set("timeoutSeconds", fmt.Sprintf("%d", watchTimeoutSeconds))
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+
+	reqURL := fmt.Sprintf("%s%s?%s", c.baseURL, apiPath, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Accept", "application/json")
+
+	logrus.WithContext(ctx).Debugf("Making request: GET %s", reqURL)
+	resp, err := c.watchClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusGone {
+		return "", errWatchExpired
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", newAPIError(resp.StatusCode, respBody)
+	}
+
+	lastVersion := resourceVersion
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event WatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if err == io.EOF {
+				return lastVersion, nil
+			}
+			return lastVersion, fmt.Errorf("failed to decode watch event: %w", err)
+		}
+
+		switch event.Type {
+		case "ERROR":
+			// The object is a metav1.Status; 410 means the version expired
+			if code, _ := event.Object["code"].(float64); code == http.StatusGone {
+				return "", errWatchExpired
+			}
+			message, _ := event.Object["message"].(string)
+			return lastVersion, fmt.Errorf("watch error: %s", message)
+		case "BOOKMARK":
+			if rv := objectResourceVersion(event.Object); rv != "" {
+				lastVersion = rv
+			}
+		default:
+			if rv := objectResourceVersion(event.Object); rv != "" {
+				lastVersion = rv
+			}
+			handler(event)
+		}
+	}
+}
+
+func watchQuery(opts WatchOptions) url.Values {
+	query := url.Values{}
+	if opts.FieldSelector != "" {
+		query.Set("fieldSelector", opts.FieldSelector)
+	}
+	if opts.LabelSelector != "" {
+		query.Set("labelSelector", opts.LabelSelector)
+	}
+	return query
+}
+
+func objectResourceVersion(object map[string]interface{}) string {
+	metadata, _ := object["metadata"].(map[string]interface{})
+	rv, _ := metadata["resourceVersion"].(string)
+	return rv
+}
+
+func nextWatchBackoff(current time.Duration) time.Duration {
+	next := current * 2
+	if next > watchMaxBackoff {
+		return watchMaxBackoff
+	}
+	return next
+}
+
+// sleepContext waits for d and reports false if ctx was cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
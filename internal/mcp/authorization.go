@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// ToolAuthorizer reports whether the caller of the request in ctx may see
+// and call tool
+type ToolAuthorizer func(ctx context.Context, tool string) bool
+
+// SetToolAuthorizer restricts tools/list and tools/call to the tools
+// authorizer allows, along with the resources and completions tied to those
+// tools. Without one every caller may use every tool.
+func (s *Server) SetToolAuthorizer(authorizer ToolAuthorizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolAuthorizer = authorizer
+}
+
+// toolAllowed is called with s.mu held
+func (s *Server) toolAllowed(ctx context.Context, tool string) bool {
+	return s.toolAuthorizer == nil || s.toolAuthorizer(ctx, tool)
+}
+
+// ToolAllowed reports whether the caller of the request in ctx may use tool
+func (s *Server) ToolAllowed(ctx context.Context, tool string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.toolAllowed(ctx, tool)
+}
+
+// SetResourceTool ties a registered resource URI or resource template to the
+// tool that returns the same data. Callers that may not use tool cannot list,
+// read, subscribe to or complete the resource either.
+func (s *Server) SetResourceTool(uriOrTemplate, tool string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.resources[uriOrTemplate]; !exists && s.findResourceTemplate(uriOrTemplate) == nil {
+		return fmt.Errorf("resource not registered: %s", uriOrTemplate)
+	}
+	s.resourceTools[uriOrTemplate] = tool
+	return nil
+}
+
+// resourceAllowed reports whether the caller may use the resource registered
+// as uriOrTemplate. It is called with s.mu held.
+func (s *Server) resourceAllowed(ctx context.Context, uriOrTemplate string) bool {
+	tool, ok := s.resourceTools[uriOrTemplate]
+	return !ok || s.toolAllowed(ctx, tool)
+}
+
+// forbiddenResourceError is returned for resources the caller may not use
+func forbiddenResourceError(uri string) *JSONRPCError {
+	return &JSONRPCError{
+		Code:    -32001,
+		Message: fmt.Sprintf("Forbidden: not permitted to read resource %s", uri),
+		Data:    map[string]interface{}{"uri": uri},
+	}
+}
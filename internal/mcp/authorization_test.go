@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+type identityKey struct{}
+
+// newAuthorizedServer serves a clusters resource and template mirroring the
+// list_clusters and get_cluster tools. The caller "viewer" may only use
+// list_clusters; any other caller may use every tool.
+func newAuthorizedServer(t *testing.T) *Server {
+	t.Helper()
+	s := NewServer("test", "1")
+	read := func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		return map[string]interface{}{"uri": uri}, nil
+	}
+	s.RegisterTool("list_clusters", "List clusters", noopTool)
+	s.RegisterTool("get_cluster", "Get a cluster", noopTool)
+	s.RegisterResource(Resource{URI: "test://clusters", Name: "clusters"}, read)
+	if err := s.RegisterResourceTemplate(ResourceTemplate{URITemplate: "test://clusters/{name}", Name: "cluster"}, read); err != nil {
+		t.Fatal(err)
+	}
+	for uri, tool := range map[string]string{"test://clusters": "list_clusters", "test://clusters/{name}": "get_cluster"} {
+		if err := s.SetResourceTool(uri, tool); err != nil {
+			t.Fatalf("SetResourceTool failed: %v", err)
+		}
+	}
+	s.SetResourceWatcher("test://clusters/{name}", func(ctx context.Context, uri string, params map[string]string, notify func()) error {
+		<-ctx.Done()
+		return nil
+	})
+	complete := func(ctx context.Context, value string, arguments map[string]string) ([]string, error) {
+		return []string{"c-1"}, nil
+	}
+	s.RegisterCompletion(CompletionReference{Type: "ref/resource", URI: "test://clusters/{name}"}, "name", complete)
+	s.RegisterCompletion(CompletionReference{Type: "ref/tool", Name: "get_cluster"}, "name", complete)
+
+	s.SetToolAuthorizer(func(ctx context.Context, tool string) bool {
+		return ctx.Value(identityKey{}) != "viewer" || tool == "list_clusters"
+	})
+	return s
+}
+
+// callAs sends a request from the session as identity
+func (ts *testSession) callAs(s *Server, identity, method string, params map[string]interface{}) *JSONRPCResponse {
+	ctx := WithSession(context.WithValue(context.Background(), identityKey{}, identity), ts.Session)
+	return s.HandleRequest(ctx, &JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+}
+
+func TestResourceAuthorization(t *testing.T) {
+	s := newAuthorizedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+	cluster := map[string]interface{}{"uri": "test://clusters/c-1"}
+
+	tests := []struct {
+		method string
+		params map[string]interface{}
+	}{
+		{"resources/read", cluster},
+		{"resources/subscribe", cluster},
+	}
+	for _, tt := range tests {
+		if resp := sess.callAs(s, "viewer", tt.method, tt.params); resp.Error == nil || resp.Error.Code != -32001 {
+			t.Errorf("%s as viewer = %+v, want -32001", tt.method, resp.Error)
+		}
+		if resp := sess.callAs(s, "admin", tt.method, tt.params); resp.Error != nil {
+			t.Errorf("%s as admin failed: %s", tt.method, resp.Error.Message)
+		}
+	}
+
+	// The list resource mirrors a tool the viewer may use
+	if resp := sess.callAs(s, "viewer", "resources/read", map[string]interface{}{"uri": "test://clusters"}); resp.Error != nil {
+		t.Errorf("reading test://clusters as viewer failed: %s", resp.Error.Message)
+	}
+	// Unknown resources are still reported as not found
+	if resp := sess.callAs(s, "viewer", "resources/read", map[string]interface{}{"uri": "test://other"}); resp.Error == nil || resp.Error.Code != -32002 {
+		t.Errorf("reading an unknown resource = %+v, want -32002", resp.Error)
+	}
+}
+
+func TestResourceListAuthorization(t *testing.T) {
+	s := newAuthorizedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	templates := sess.callAs(s, "viewer", "resources/templates/list", nil).Result.(ResourceTemplateListResponse)
+	if len(templates.ResourceTemplates) != 0 {
+		t.Errorf("templates listed for viewer = %v, want none", templates.ResourceTemplates)
+	}
+	resources := sess.callAs(s, "viewer", "resources/list", nil).Result.(ResourceListResponse)
+	if len(resources.Resources) != 1 {
+		t.Errorf("resources listed for viewer = %v, want test://clusters", resources.Resources)
+	}
+	templates = sess.callAs(s, "admin", "resources/templates/list", nil).Result.(ResourceTemplateListResponse)
+	if len(templates.ResourceTemplates) != 1 {
+		t.Errorf("templates listed for admin = %v, want one", templates.ResourceTemplates)
+	}
+}
+
+func TestCompletionAuthorization(t *testing.T) {
+	s := newAuthorizedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	for _, ref := range []map[string]interface{}{
+		{"type": "ref/resource", "uri": "test://clusters/{name}"},
+		{"type": "ref/tool", "name": "get_cluster"},
+	} {
+		params := map[string]interface{}{"ref": ref, "argument": map[string]interface{}{"name": "name", "value": ""}}
+		denied := sess.callAs(s, "viewer", "completion/complete", params).Result.(CompleteResponse)
+		if len(denied.Completion.Values) != 0 {
+			t.Errorf("completion of %v as viewer = %v, want none", ref, denied.Completion.Values)
+		}
+		allowed := sess.callAs(s, "admin", "completion/complete", params).Result.(CompleteResponse)
+		if len(allowed.Completion.Values) != 1 {
+			t.Errorf("completion of %v as admin = %v, want [c-1]", ref, allowed.Completion.Values)
+		}
+	}
+}
+
+func TestSetResourceToolUnknownResource(t *testing.T) {
+	s := NewServer("test", "1")
+	if err := s.SetResourceTool("test://missing", "list_clusters"); err == nil {
+		t.Error("SetResourceTool accepted an unregistered resource")
+	}
+}
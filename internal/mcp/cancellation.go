@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type inflightRequest struct {
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// requestKey identifies a request within its session. The ID is encoded as
+// JSON so that the number 1 and the string "1" stay distinct.
+func requestKey(ctx context.Context, id interface{}) string {
+	sessionID := ""
+	if sess, ok := SessionFromContext(ctx); ok {
+		sessionID = sess.id
+	}
+	encoded, _ := json.Marshal(id)
+	return sessionID + "/" + string(encoded)
+}
+
+// trackRequest derives a cancelable context for an in-flight request. The
+// returned function must be called when the request completes and reports
+// whether the client cancelled it in the meantime.
+func (s *Server) trackRequest(ctx context.Context, id interface{}) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	key := requestKey(ctx, id)
+	entry := &inflightRequest{cancel: cancel}
+
+	s.inflightMu.Lock()
+	s.inflight[key] = entry
+	s.inflightMu.Unlock()
+
+	return ctx, func() bool {
+		s.inflightMu.Lock()
+		if s.inflight[key] == entry {
+			delete(s.inflight, key)
+		}
+		cancelled := entry.cancelled
+		s.inflightMu.Unlock()
+
+		cancel()
+		return cancelled
+	}
+}
+
+// handleCancelled aborts the request named by a notifications/cancelled message
+func (s *Server) handleCancelled(ctx context.Context, req *JSONRPCRequest) {
+	id, ok := req.Params["requestId"]
+	if !ok || id == nil {
+		return
+	}
+
+	key := requestKey(ctx, id)
+
+	s.inflightMu.Lock()
+	entry, exists := s.inflight[key]
+	if exists {
+		entry.cancelled = true
+		delete(s.inflight, key)
+	}
+	s.inflightMu.Unlock()
+
+	if exists {
+		entry.cancel()
+	}
+}
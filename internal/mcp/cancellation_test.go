@@ -0,0 +1,211 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+)
+
+// stdioClient drives Server.Serve over pipes, as a client on stdio would
+type stdioClient struct {
+	in       *io.PipeWriter
+	messages chan map[string]interface{}
+}
+
+func newStdioClient(t *testing.T, s *Server) *stdioClient {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &stdioClient{in: inW, messages: make(chan map[string]interface{}, 16)}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Serve(context.Background(), inR, outW)
+		outW.Close()
+	}()
+	go func() {
+		decoder := json.NewDecoder(outR)
+		for {
+			var msg map[string]interface{}
+			if err := decoder.Decode(&msg); err != nil {
+				close(c.messages)
+				return
+			}
+			c.messages <- msg
+		}
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		<-done
+	})
+
+	c.send(t, map[string]interface{}{"jsonrpc": "2.0", "id": 0, "method": "initialize", "params": map[string]interface{}{
+		"protocolVersion": ProtocolVersion20250618,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "test", "version": "1"},
+	}})
+	c.next(t)
+	return c
+}
+
+func (c *stdioClient) send(t *testing.T, msg map[string]interface{}) {
+	t.Helper()
+	data, _ := json.Marshal(msg)
+	if _, err := c.in.Write(append(data, '\n')); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+}
+
+// call sends a tools/call request for tool with the given id
+func (c *stdioClient) call(t *testing.T, id int, tool string, extra map[string]interface{}) {
+	t.Helper()
+	params := map[string]interface{}{"name": tool, "arguments": map[string]interface{}{}}
+	for k, v := range extra {
+		params[k] = v
+	}
+	c.send(t, map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": "tools/call", "params": params})
+}
+
+// cancel sends notifications/cancelled for the request id
+func (c *stdioClient) cancel(t *testing.T, id int) {
+	t.Helper()
+	c.send(t, map[string]interface{}{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": map[string]interface{}{"requestId": id}})
+}
+
+// next returns the next message written by the server
+func (c *stdioClient) next(t *testing.T) map[string]interface{} {
+	t.Helper()
+	select {
+	case msg, ok := <-c.messages:
+		if !ok {
+			t.Fatal("server closed its output")
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message from the server")
+		return nil
+	}
+}
+
+// nextResponse skips notifications and returns the next response
+func (c *stdioClient) nextResponse(t *testing.T) map[string]interface{} {
+	t.Helper()
+	for {
+		if msg := c.next(t); msg["id"] != nil {
+			return msg
+		}
+	}
+}
+
+// expectNoResponse fails the test if a response arrives within wait
+func (c *stdioClient) expectNoResponse(t *testing.T, wait time.Duration) {
+	t.Helper()
+	timeout := time.After(wait)
+	for {
+		select {
+		case msg := <-c.messages:
+			if msg["id"] != nil {
+				t.Fatalf("unexpected response %v", msg)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// blockingTool returns a tool that reports when it starts and then waits for
+// release or for its context to end, reporting the latter on cancelled
+func blockingTool(started chan<- struct{}, release <-chan struct{}, cancelled chan<- struct{}) ToolHandler {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return "slow", nil
+		case <-ctx.Done():
+			cancelled <- struct{}{}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestSlowCallDoesNotBlockFastCall(t *testing.T) {
+	s := NewServer("test", "1")
+	started, release, cancelled := make(chan struct{}, 1), make(chan struct{}), make(chan struct{}, 1)
+	s.RegisterTool("slow", "Blocks until released", blockingTool(started, release, cancelled))
+	s.RegisterTool("fast", "Returns at once", noopTool)
+	c := newStdioClient(t, s)
+
+	c.call(t, 1, "slow", nil)
+	<-started
+	c.call(t, 2, "fast", nil)
+	if resp := c.nextResponse(t); resp["id"] != float64(2) {
+		t.Fatalf("first response = %v, want the fast call", resp)
+	}
+
+	close(release)
+	if resp := c.nextResponse(t); resp["id"] != float64(1) || resp["error"] != nil {
+		t.Fatalf("second response = %v, want the slow call's result", resp)
+	}
+}
+
+func TestCancelledCallHasNoResponse(t *testing.T) {
+	s := NewServer("test", "1")
+	started, cancelled := make(chan struct{}, 1), make(chan struct{}, 1)
+	s.RegisterTool("slow", "Blocks until cancelled", blockingTool(started, nil, cancelled))
+	s.RegisterTool("fast", "Returns at once", noopTool)
+	c := newStdioClient(t, s)
+
+	c.call(t, 1, "slow", nil)
+	<-started
+	c.cancel(t, 1)
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the cancelled call's context was not cancelled")
+	}
+
+	// The next response belongs to a later request; the cancelled one never
+	// gets one
+	c.call(t, 2, "fast", nil)
+	if resp := c.nextResponse(t); resp["id"] != float64(2) {
+		t.Fatalf("response = %v, want only the one for request 2", resp)
+	}
+	c.expectNoResponse(t, 50*time.Millisecond)
+}
+
+func TestCancelUnknownOrFinishedRequest(t *testing.T) {
+	s := NewServer("test", "1")
+	started, release, cancelled := make(chan struct{}, 1), make(chan struct{}), make(chan struct{}, 1)
+	s.RegisterTool("slow", "Blocks until released", blockingTool(started, release, cancelled))
+	s.RegisterTool("fast", "Returns at once", noopTool)
+	c := newStdioClient(t, s)
+
+	c.call(t, 1, "fast", nil)
+	c.nextResponse(t)
+	c.call(t, 2, "slow", nil)
+	<-started
+
+	// Neither a finished request nor an id that was never sent affects the
+	// call in flight
+	c.cancel(t, 1)
+	c.cancel(t, 99)
+	c.send(t, map[string]interface{}{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": map[string]interface{}{}})
+	close(release)
+	if resp := c.nextResponse(t); resp["id"] != float64(2) || resp["error"] != nil {
+		t.Fatalf("response = %v, want the result of request 2", resp)
+	}
+	select {
+	case <-cancelled:
+		t.Error("a call was cancelled by a notification for another request")
+	default:
+	}
+
+	// Reusing the id of a cancelled-after-finish request works normally
+	c.call(t, 1, "fast", nil)
+	if resp := c.nextResponse(t); resp["id"] != float64(1) || resp["error"] != nil {
+		t.Fatalf("response = %v, want the result of the new request 1", resp)
+	}
+}
@@ -0,0 +1,109 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxCompletionValues is the most values a completion result may carry
+const maxCompletionValues = 100
+
+// CompletionReference identifies what is being completed: a prompt
+// ("ref/prompt" with Name), a resource template ("ref/resource" with URI), or,
+// as an extension to the spec, a tool ("ref/tool" with Name)
+type CompletionReference struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// CompletionHandler suggests values for an argument given the partial value
+// typed so far. arguments holds the other arguments already filled in, when
+// the client sends them.
+type CompletionHandler func(ctx context.Context, value string, arguments map[string]string) ([]string, error)
+
+func completionKey(ref CompletionReference, argument string) string {
+	target := ref.Name
+	if ref.Type == "ref/resource" {
+		target = ref.URI
+	}
+	return ref.Type + " " + target + " " + argument
+}
+
+// RegisterCompletion registers the completer for one argument of a prompt,
+// resource template or tool
+func (s *Server) RegisterCompletion(ref CompletionReference, argument string, handler CompletionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.completionHandlers[completionKey(ref, argument)] = handler
+}
+
+func (s *Server) handleCompletionComplete(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	var params struct {
+		Ref      CompletionReference `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	}
+	if err := decodeParams(req.Params, &params); err != nil || params.Ref.Type == "" || params.Argument.Name == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: ref and argument.name are required",
+			},
+		}
+	}
+
+	s.mu.RLock()
+	handler, exists := s.completionHandlers[completionKey(params.Ref, params.Argument.Name)]
+	switch params.Ref.Type {
+	case "ref/tool":
+		// Removed, disabled and forbidden tools keep their completers but
+		// suggest nothing
+		_, registered := s.tools[params.Ref.Name]
+		exists = exists && registered && !s.disabledTools[params.Ref.Name] && s.toolAllowed(ctx, params.Ref.Name)
+	case "ref/resource":
+		exists = exists && s.resourceAllowed(ctx, params.Ref.URI)
+	}
+	s.mu.RUnlock()
+
+	// Arguments without a completer simply have no suggestions
+	values := []string{}
+	if exists {
+		suggestions, err := handler(ctx, params.Argument.Value, params.Context.Arguments)
+		if err != nil {
+			return &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &JSONRPCError{
+					Code:    -32603,
+					Message: fmt.Sprintf("Internal error: %s", s.formatError(err)),
+				},
+			}
+		}
+		if suggestions != nil {
+			values = suggestions
+		}
+	}
+
+	result := CompletionResult{
+		Values: values,
+		Total:  len(values),
+	}
+	if len(values) > maxCompletionValues {
+		result.Values = values[:maxCompletionValues]
+		result.HasMore = true
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  CompleteResponse{Completion: result},
+	}
+}
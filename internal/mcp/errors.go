@@ -0,0 +1,24 @@
+package mcp
+
+// ErrorFormatter turns an error returned by a tool, resource or completion
+// handler into the text sent to the client
+type ErrorFormatter func(err error) string
+
+// SetErrorFormatter sets how handler errors are described to clients.
+// Without one the error's own message is used.
+func (s *Server) SetErrorFormatter(formatter ErrorFormatter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errorFormatter = formatter
+}
+
+func (s *Server) formatError(err error) string {
+	s.mu.RLock()
+	formatter := s.errorFormatter
+	s.mu.RUnlock()
+
+	if formatter == nil {
+		return err.Error()
+	}
+	return formatter(err)
+}
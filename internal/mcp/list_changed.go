@@ -0,0 +1,45 @@
+package mcp
+
+import "time"
+
+// toolsChangedDelay coalesces bursts of registry changes, such as disabling a
+// whole tool group, into a single notification
+const toolsChangedDelay = 50 * time.Millisecond
+
+// toolsChanged schedules notifications/tools/list_changed for every session
+// that may hold a stale tool list. It is called with s.mu held, so delivery
+// happens on a separate goroutine.
+func (s *Server) toolsChanged() {
+	s.toolsChangedMu.Lock()
+	defer s.toolsChangedMu.Unlock()
+
+	s.toolsChangedAt = time.Now()
+	if s.toolsChangedPending {
+		return
+	}
+	s.toolsChangedPending = true
+	time.AfterFunc(toolsChangedDelay, s.broadcastToolsChanged)
+}
+
+func (s *Server) broadcastToolsChanged() {
+	s.toolsChangedMu.Lock()
+	s.toolsChangedPending = false
+	changedAt := s.toolsChangedAt
+	s.toolsChangedMu.Unlock()
+
+	s.mu.RLock()
+	sessions := make([]*Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mu.RUnlock()
+
+	for _, sess := range sessions {
+		// Sessions initialized after the change already listed the new
+		// tools, and uninitialized ones will list them anyway
+		if !sess.initializedBefore(changedAt) {
+			continue
+		}
+		sess.Notify("notifications/tools/list_changed", nil)
+	}
+}
@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testSession is a session whose notifications are collected on a channel
+type testSession struct {
+	*Session
+	notifications chan *JSONRPCNotification
+}
+
+// newTestSession opens a session on s. It is initialized with version unless
+// version is empty.
+func newTestSession(t *testing.T, s *Server, version string) *testSession {
+	t.Helper()
+	notifications := make(chan *JSONRPCNotification, 16)
+	sess := s.NewSession(func(n *JSONRPCNotification) error {
+		notifications <- n
+		return nil
+	})
+	t.Cleanup(func() { s.CloseSession(sess.ID()) })
+
+	ts := &testSession{Session: sess, notifications: notifications}
+	if version != "" {
+		resp := ts.call(s, "initialize", map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{},
+			"clientInfo":      map[string]interface{}{"name": "test", "version": "1"},
+		})
+		if resp.Error != nil {
+			t.Fatalf("initialize failed: %s", resp.Error.Message)
+		}
+	}
+	return ts
+}
+
+// call sends a request from the session
+func (ts *testSession) call(s *Server, method string, params map[string]interface{}) *JSONRPCResponse {
+	ctx := WithSession(context.Background(), ts.Session)
+	return s.HandleRequest(ctx, &JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+}
+
+// waitFor returns the next notification with method, failing the test if
+// none arrives in time
+func (ts *testSession) waitFor(t *testing.T, method string) *JSONRPCNotification {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case n := <-ts.notifications:
+			if n.Method == method {
+				return n
+			}
+		case <-timeout:
+			t.Fatalf("%s was not sent", method)
+			return nil
+		}
+	}
+}
+
+// expectNone fails the test if a notification with method arrives within
+// wait
+func (ts *testSession) expectNone(t *testing.T, method string, wait time.Duration) {
+	t.Helper()
+	timeout := time.After(wait)
+	for {
+		select {
+		case n := <-ts.notifications:
+			if n.Method == method {
+				t.Fatalf("unexpected %s", method)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func noopTool(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestToolsListChanged(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(s *Server)
+	}{
+		{"register", func(s *Server) { s.RegisterTool("added", "Added", noopTool) }},
+		{"unregister", func(s *Server) { s.UnregisterTool("existing") }},
+		{"disable", func(s *Server) { s.SetToolEnabled("existing", false) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("test", "1")
+			s.RegisterTool("existing", "Existing", noopTool)
+			// Let the registration above settle before any session exists
+			time.Sleep(2 * toolsChangedDelay)
+
+			sess := newTestSession(t, s, ProtocolVersion20250618)
+			uninitialized := newTestSession(t, s, "")
+			time.Sleep(time.Millisecond)
+
+			tt.change(s)
+			sess.waitFor(t, "notifications/tools/list_changed")
+			uninitialized.expectNone(t, "notifications/tools/list_changed", 2*toolsChangedDelay)
+		})
+	}
+}
+
+func TestToolsListChangedCoalesced(t *testing.T) {
+	s := NewServer("test", "1")
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+	time.Sleep(time.Millisecond)
+
+	for _, name := range []string{"a", "b", "c"} {
+		s.RegisterTool(name, name, noopTool)
+	}
+	sess.waitFor(t, "notifications/tools/list_changed")
+	sess.expectNone(t, "notifications/tools/list_changed", 2*toolsChangedDelay)
+}
+
+func TestSetToolEnabled(t *testing.T) {
+	s := NewServer("test", "1")
+	s.RegisterTool("frozen", "Frozen", noopTool)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	if err := s.SetToolEnabled("missing", false); err == nil {
+		t.Error("SetToolEnabled on an unknown tool succeeded")
+	}
+	if err := s.SetToolEnabled("frozen", false); err != nil {
+		t.Fatalf("SetToolEnabled failed: %v", err)
+	}
+
+	list := sess.call(s, "tools/list", nil).Result.(ToolListResponse)
+	if len(list.Tools) != 0 {
+		t.Errorf("tools/list = %v, want no tools", list.Tools)
+	}
+	resp := sess.call(s, "tools/call", map[string]interface{}{"name": "frozen"})
+	if resp.Error == nil || resp.Error.Code != -32601 {
+		t.Errorf("tools/call of a disabled tool = %+v, want -32601", resp.Error)
+	}
+
+	if err := s.SetToolEnabled("frozen", true); err != nil {
+		t.Fatalf("SetToolEnabled failed: %v", err)
+	}
+	if resp := sess.call(s, "tools/call", map[string]interface{}{"name": "frozen"}); resp.Error != nil {
+		t.Errorf("tools/call after enabling failed: %s", resp.Error.Message)
+	}
+}
@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// LoggingLevel is a syslog severity as used by logging/setLevel and
+// notifications/message
+type LoggingLevel string
+
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// loggingLevelSeverity orders the levels from least to most severe
+var loggingLevelSeverity = map[LoggingLevel]int{
+	LoggingLevelDebug:     0,
+	LoggingLevelInfo:      1,
+	LoggingLevelNotice:    2,
+	LoggingLevelWarning:   3,
+	LoggingLevelError:     4,
+	LoggingLevelCritical:  5,
+	LoggingLevelAlert:     6,
+	LoggingLevelEmergency: 7,
+}
+
+// Valid reports whether l is one of the defined levels
+func (l LoggingLevel) Valid() bool {
+	_, ok := loggingLevelSeverity[l]
+	return ok
+}
+
+// enabled reports whether a message at level passes the threshold l
+func (l LoggingLevel) enabled(level LoggingLevel) bool {
+	return loggingLevelSeverity[level] >= loggingLevelSeverity[l]
+}
+
+// SetDefaultLogLevel sets the level sessions start with until the client
+// calls logging/setLevel
+func (s *Server) SetDefaultLogLevel(level LoggingLevel) error {
+	if !level.Valid() {
+		return fmt.Errorf("invalid logging level: %s", level)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultLogLevel = level
+	return nil
+}
+
+// LogMessage sends notifications/message to clients whose level admits it.
+// If ctx belongs to a request the message only goes to the client that made
+// it. Otherwise it goes to the stdio session, if any: HTTP sessions may belong
+// to different users, and entries such as failed authentications or watcher
+// errors are not theirs to see.
+func (s *Server) LogMessage(ctx context.Context, level LoggingLevel, logger string, data interface{}) {
+	params := &LoggingMessageNotification{
+		Level:  level,
+		Logger: logger,
+		Data:   data,
+	}
+
+	if sess, ok := SessionFromContext(ctx); ok {
+		if sess.ProtocolVersion() != "" && s.sessionLogLevel(sess).enabled(level) {
+			Notify(ctx, "notifications/message", params)
+		}
+		return
+	}
+
+	s.mu.RLock()
+	sessions := make([]*Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		if sess.serverLogs {
+			sessions = append(sessions, sess)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, sess := range sessions {
+		if sess.ProtocolVersion() != "" && s.sessionLogLevel(sess).enabled(level) {
+			sess.Notify("notifications/message", params)
+		}
+	}
+}
+
+// sessionLogLevel returns the level the session selected, or the default
+func (s *Server) sessionLogLevel(sess *Session) LoggingLevel {
+	if level := sess.LogLevel(); level != "" {
+		return level
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.defaultLogLevel
+}
+
+func (s *Server) handleLoggingSetLevel(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	level, _ := req.Params["level"].(string)
+	if !LoggingLevel(level).Valid() {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: fmt.Sprintf("Invalid params: unknown logging level %q", level),
+			},
+		}
+	}
+
+	sess, ok := SessionFromContext(ctx)
+	if !ok {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32600,
+				Message: "Invalid Request: logging/setLevel requires a session",
+			},
+		}
+	}
+	sess.setLogLevel(LoggingLevel(level))
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]interface{}{},
+	}
+}
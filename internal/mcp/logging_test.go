@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// logLevels returns the levels of the notifications/message the session has
+// received so far
+func (ts *testSession) logLevels() []LoggingLevel {
+	var levels []LoggingLevel
+	for {
+		select {
+		case n := <-ts.notifications:
+			if n.Method == "notifications/message" {
+				levels = append(levels, n.Params.(*LoggingMessageNotification).Level)
+			}
+		case <-time.After(10 * time.Millisecond):
+			return levels
+		}
+	}
+}
+
+func logEveryLevel(s *Server, ctx context.Context) {
+	for _, level := range []LoggingLevel{LoggingLevelDebug, LoggingLevelInfo, LoggingLevelWarning, LoggingLevelError} {
+		s.LogMessage(ctx, level, "test", string(level))
+	}
+}
+
+func TestLoggingSetLevel(t *testing.T) {
+	s := NewServer("test", "1")
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+	ctx := WithSession(context.Background(), sess.Session)
+
+	// Sessions start at the server's default level
+	logEveryLevel(s, ctx)
+	if got := sess.logLevels(); len(got) != 3 || got[0] != LoggingLevelInfo {
+		t.Errorf("levels at the default = %v, want info, warning and error", got)
+	}
+
+	if resp := sess.call(s, "logging/setLevel", map[string]interface{}{"level": "warning"}); resp.Error != nil {
+		t.Fatalf("logging/setLevel failed: %s", resp.Error.Message)
+	}
+	logEveryLevel(s, ctx)
+	if got := sess.logLevels(); len(got) != 2 || got[0] != LoggingLevelWarning {
+		t.Errorf("levels at warning = %v, want warning and error", got)
+	}
+}
+
+func TestLoggingSetLevelInvalid(t *testing.T) {
+	s := NewServer("test", "1")
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	resp := sess.call(s, "logging/setLevel", map[string]interface{}{"level": "verbose"})
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Errorf("logging/setLevel verbose = %+v, want -32602", resp.Error)
+	}
+	if err := s.SetDefaultLogLevel("verbose"); err == nil {
+		t.Error("SetDefaultLogLevel accepted an unknown level")
+	}
+}
+
+func TestLogMessageScope(t *testing.T) {
+	s := NewServer("test", "1")
+	caller := newTestSession(t, s, ProtocolVersion20250618)
+	other := newTestSession(t, s, ProtocolVersion20250618)
+	stdio := newTestSession(t, s, ProtocolVersion20250618)
+	stdio.serverLogs = true
+
+	// An entry about a request only reaches the client that made it
+	s.LogMessage(WithSession(context.Background(), caller.Session), LoggingLevelError, "test", "request")
+	if len(caller.logLevels()) != 1 || len(other.logLevels()) != 0 || len(stdio.logLevels()) != 0 {
+		t.Error("a request entry reached a session other than the caller")
+	}
+
+	// Entries without a request, including those logged by watchers under a
+	// detached context, only reach the stdio session
+	for _, ctx := range []context.Context{context.Background(), detachContext(WithSession(context.Background(), caller.Session))} {
+		s.LogMessage(ctx, LoggingLevelError, "test", "server")
+		if len(caller.logLevels()) != 0 || len(other.logLevels()) != 0 {
+			t.Error("a server entry reached an HTTP session")
+		}
+		if len(stdio.logLevels()) != 1 {
+			t.Error("a server entry did not reach the stdio session")
+		}
+	}
+}
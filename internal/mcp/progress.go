@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+)
+
+// progressReporter emits notifications/progress for a single request
+type progressReporter struct {
+	token interface{}
+
+	mu   sync.Mutex
+	last float64
+}
+
+type progressContextKey struct{}
+
+// withProgressToken attaches a reporter for the caller's progressToken
+func withProgressToken(ctx context.Context, token interface{}) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, &progressReporter{token: token, last: -1})
+}
+
+// ReportProgress tells the caller how far a long-running tool call has got.
+// total may be 0 when it is unknown. It is a no-op unless the caller asked
+// for progress by sending _meta.progressToken, and reports that do not
+// advance past the previous one are dropped, as the spec requires progress
+// to increase.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	reporter, ok := ctx.Value(progressContextKey{}).(*progressReporter)
+	if !ok {
+		return
+	}
+
+	reporter.mu.Lock()
+	if progress <= reporter.last {
+		reporter.mu.Unlock()
+		return
+	}
+	reporter.last = progress
+	reporter.mu.Unlock()
+
+	Notify(ctx, "notifications/progress", ProgressNotification{
+		ProgressToken: reporter.token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+}
+
+// progressToken extracts _meta.progressToken from request params
+func progressToken(params map[string]interface{}) (interface{}, bool) {
+	meta, ok := params["_meta"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	token, ok := meta["progressToken"]
+	return token, ok && token != nil
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// progressTool reports progress 1, 1, 2 and 0.5 out of 2 and returns
+func progressTool(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	for _, progress := range []float64{1, 1, 2, 0.5} {
+		ReportProgress(ctx, progress, 2, "working")
+	}
+	return "done", nil
+}
+
+func TestProgressCarriesToken(t *testing.T) {
+	s := NewServer("test", "1")
+	s.RegisterTool("work", "Reports progress", progressTool)
+	c := newStdioClient(t, s)
+
+	c.call(t, 1, "work", map[string]interface{}{"_meta": map[string]interface{}{"progressToken": "job-1"}})
+
+	// Reports that do not advance are dropped
+	var progress []float64
+	for {
+		msg := c.next(t)
+		if msg["id"] != nil {
+			if msg["id"] != float64(1) || msg["error"] != nil {
+				t.Fatalf("response = %v, want the result of request 1", msg)
+			}
+			break
+		}
+		if msg["method"] != "notifications/progress" {
+			continue
+		}
+		params := msg["params"].(map[string]interface{})
+		if params["progressToken"] != "job-1" {
+			t.Errorf("progressToken = %v, want job-1", params["progressToken"])
+		}
+		if params["total"] != float64(2) || params["message"] != "working" {
+			t.Errorf("progress params = %v", params)
+		}
+		progress = append(progress, params["progress"].(float64))
+	}
+	if len(progress) != 2 || progress[0] != 1 || progress[1] != 2 {
+		t.Errorf("progress = %v, want [1 2]", progress)
+	}
+}
+
+func TestNoProgressWithoutToken(t *testing.T) {
+	s := NewServer("test", "1")
+	s.RegisterTool("work", "Reports progress", progressTool)
+	c := newStdioClient(t, s)
+
+	c.call(t, 1, "work", nil)
+	if msg := c.next(t); msg["id"] != float64(1) {
+		t.Fatalf("first message = %v, want the response to request 1", msg)
+	}
+	select {
+	case msg := <-c.messages:
+		t.Errorf("unexpected message %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PromptHandler expands a prompt into messages using the caller's arguments
+type PromptHandler func(ctx context.Context, args map[string]string) ([]PromptMessage, error)
+
+// RegisterPrompt registers a prompt template
+func (s *Server) RegisterPrompt(prompt Prompt, handler PromptHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.prompts[prompt.Name]; !exists {
+		s.promptOrder = append(s.promptOrder, prompt.Name)
+	}
+	s.prompts[prompt.Name] = prompt
+	s.promptHandlers[prompt.Name] = handler
+}
+
+func (s *Server) handlePromptsList(req *JSONRPCRequest) *JSONRPCResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	prompts := make([]Prompt, 0, len(s.promptOrder))
+	for _, name := range s.promptOrder {
+		prompts = append(prompts, s.prompts[name])
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: PromptListResponse{
+			Prompts: prompts,
+		},
+	}
+}
+
+func (s *Server) handlePromptsGet(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	name, ok := req.Params["name"].(string)
+	if !ok || name == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: name is required",
+			},
+		}
+	}
+
+	s.mu.RLock()
+	prompt, exists := s.prompts[name]
+	handler := s.promptHandlers[name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: fmt.Sprintf("Prompt not found: %s", name),
+			},
+		}
+	}
+
+	// Prompt arguments are always strings per the spec
+	args := make(map[string]string)
+	if rawArgs, ok := req.Params["arguments"].(map[string]interface{}); ok {
+		for key, value := range rawArgs {
+			if str, ok := value.(string); ok {
+				args[key] = str
+			} else if value != nil {
+				args[key] = fmt.Sprintf("%v", value)
+			}
+		}
+	}
+
+	var missing []string
+	for _, arg := range prompt.Arguments {
+		if arg.Required && strings.TrimSpace(args[arg.Name]) == "" {
+			missing = append(missing, arg.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: fmt.Sprintf("Invalid params: missing required arguments: %s", strings.Join(missing, ", ")),
+			},
+		}
+	}
+
+	messages, err := handler(ctx, args)
+	if err != nil {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32603,
+				Message: fmt.Sprintf("Internal error: %v", err),
+			},
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: GetPromptResponse{
+			Description: prompt.Description,
+			Messages:    messages,
+		},
+	}
+}
@@ -0,0 +1,282 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ResourceHandler reads a resource. params holds the variables extracted from
+// the URI when the resource was registered as a template, and is empty for
+// static resources.
+type ResourceHandler func(ctx context.Context, uri string, params map[string]string) (interface{}, error)
+
+type resourceTemplateEntry struct {
+	template ResourceTemplate
+	pattern  *regexp.Regexp
+	vars     []string
+	handler  ResourceHandler
+	watcher  ResourceWatcher
+}
+
+var templateVarPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RegisterResource registers a resource with a fixed URI
+func (s *Server) RegisterResource(resource Resource, handler ResourceHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resource.MimeType == "" {
+		resource.MimeType = "application/json"
+	}
+
+	if _, exists := s.resources[resource.URI]; !exists {
+		s.resourceOrder = append(s.resourceOrder, resource.URI)
+	}
+	s.resources[resource.URI] = resource
+	s.resourceHandlers[resource.URI] = handler
+}
+
+// RegisterResourceTemplate registers a parameterized resource such as
+// rancher://clusters/{name}. Each {variable} matches a single path segment.
+func (s *Server) RegisterResourceTemplate(template ResourceTemplate, handler ResourceHandler) error {
+	pattern, vars, err := compileURITemplate(template.URITemplate)
+	if err != nil {
+		return err
+	}
+
+	if template.MimeType == "" {
+		template.MimeType = "application/json"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &resourceTemplateEntry{
+		template: template,
+		pattern:  pattern,
+		vars:     vars,
+		handler:  handler,
+	}
+	for i, existing := range s.resourceTemplates {
+		if existing.template.URITemplate == template.URITemplate {
+			s.resourceTemplates[i] = entry
+			return nil
+		}
+	}
+	s.resourceTemplates = append(s.resourceTemplates, entry)
+	return nil
+}
+
+// findResourceTemplate returns the registered template with the given URI
+// template. It is called with s.mu held.
+func (s *Server) findResourceTemplate(uriTemplate string) *resourceTemplateEntry {
+	for _, entry := range s.resourceTemplates {
+		if entry.template.URITemplate == uriTemplate {
+			return entry
+		}
+	}
+	return nil
+}
+
+// compileURITemplate turns a level 1 URI template into an anchored regexp
+func compileURITemplate(uriTemplate string) (*regexp.Regexp, []string, error) {
+	if uriTemplate == "" {
+		return nil, nil, fmt.Errorf("uri template is required")
+	}
+
+	var (
+		expr strings.Builder
+		vars []string
+		last int
+	)
+	expr.WriteString("^")
+	for _, loc := range templateVarPattern.FindAllStringSubmatchIndex(uriTemplate, -1) {
+		expr.WriteString(regexp.QuoteMeta(uriTemplate[last:loc[0]]))
+		expr.WriteString("([^/]+)")
+		vars = append(vars, uriTemplate[loc[2]:loc[3]])
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(uriTemplate[last:]))
+	expr.WriteString("$")
+
+	pattern, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid uri template %q: %w", uriTemplate, err)
+	}
+	return pattern, vars, nil
+}
+
+// resourceMatch is the registered resource or template that serves a URI
+type resourceMatch struct {
+	handler  ResourceHandler
+	params   map[string]string
+	mimeType string
+	// registeredAs is the URI or URI template the resource was registered as
+	registeredAs string
+}
+
+// matchResource finds the handler responsible for uri
+func (s *Server) matchResource(uri string) (resourceMatch, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if handler, exists := s.resourceHandlers[uri]; exists {
+		return resourceMatch{
+			handler:      handler,
+			params:       map[string]string{},
+			mimeType:     s.resources[uri].MimeType,
+			registeredAs: uri,
+		}, true
+	}
+
+	for _, entry := range s.resourceTemplates {
+		matches := entry.pattern.FindStringSubmatch(uri)
+		if matches == nil {
+			continue
+		}
+		params := make(map[string]string, len(entry.vars))
+		for i, name := range entry.vars {
+			params[name] = matches[i+1]
+		}
+		return resourceMatch{
+			handler:      entry.handler,
+			params:       params,
+			mimeType:     entry.template.MimeType,
+			registeredAs: entry.template.URITemplate,
+		}, true
+	}
+
+	return resourceMatch{}, false
+}
+
+// resourceURIAllowed reports whether the caller may read uri. Unknown URIs are
+// allowed so that they are reported as not found.
+func (s *Server) resourceURIAllowed(ctx context.Context, uri string) bool {
+	match, exists := s.matchResource(uri)
+	if !exists {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resourceAllowed(ctx, match.registeredAs)
+}
+
+func (s *Server) handleResourcesList(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resources := make([]Resource, 0, len(s.resourceOrder))
+	for _, uri := range s.resourceOrder {
+		if s.resourceAllowed(ctx, uri) {
+			resources = append(resources, s.resources[uri])
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: ResourceListResponse{
+			Resources: resources,
+		},
+	}
+}
+
+func (s *Server) handleResourceTemplatesList(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	templates := make([]ResourceTemplate, 0, len(s.resourceTemplates))
+	for _, entry := range s.resourceTemplates {
+		if s.resourceAllowed(ctx, entry.template.URITemplate) {
+			templates = append(templates, entry.template)
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: ResourceTemplateListResponse{
+			ResourceTemplates: templates,
+		},
+	}
+}
+
+func (s *Server) handleResourcesRead(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	uri, ok := req.Params["uri"].(string)
+	if !ok || uri == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: uri is required",
+			},
+		}
+	}
+
+	match, exists := s.matchResource(uri)
+	if !exists {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32002,
+				Message: fmt.Sprintf("Resource not found: %s", uri),
+				Data:    map[string]interface{}{"uri": uri},
+			},
+		}
+	}
+
+	if !s.resourceURIAllowed(ctx, uri) {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   forbiddenResourceError(uri),
+		}
+	}
+
+	result, err := match.handler(ctx, uri, match.params)
+	if err != nil {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32603,
+				Message: fmt.Sprintf("Failed to read resource %s: %s", uri, s.formatError(err)),
+			},
+		}
+	}
+
+	text, ok := result.(string)
+	if !ok {
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &JSONRPCError{
+					Code:    -32603,
+					Message: fmt.Sprintf("Internal error: %v", err),
+				},
+			}
+		}
+		text = string(resultJSON)
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: ReadResourceResponse{
+			Contents: []ResourceContents{
+				{
+					URI:      uri,
+					MimeType: match.mimeType,
+					Text:     text,
+				},
+			},
+		},
+	}
+}
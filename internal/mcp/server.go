@@ -5,25 +5,67 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
+	"time"
 )
 
 type ToolHandler func(ctx context.Context, args map[string]interface{}) (interface{}, error)
 
 type Server struct {
-	name         string
-	version      string
-	tools        map[string]Tool
-	toolHandlers map[string]ToolHandler
-	mu           sync.RWMutex
+	name               string
+	version            string
+	tools              map[string]Tool
+	toolHandlers       map[string]ToolHandler
+	disabledTools      map[string]bool
+	resources          map[string]Resource
+	resourceHandlers   map[string]ResourceHandler
+	resourceOrder      []string
+	resourceTemplates  []*resourceTemplateEntry
+	resourceWatchers   map[string]ResourceWatcher
+	resourceTools      map[string]string
+	prompts            map[string]Prompt
+	promptHandlers     map[string]PromptHandler
+	promptOrder        []string
+	completionHandlers map[string]CompletionHandler
+	toolAuthorizer     ToolAuthorizer
+	errorFormatter     ErrorFormatter
+	sessions           map[string]*Session
+	mu                 sync.RWMutex
+
+	subscriptions map[string]*resourceSubscription // by caller and URI
+	subMu         sync.Mutex
+
+	inflight   map[string]*inflightRequest
+	inflightMu sync.Mutex
+
+	toolsPageSize   int
+	defaultLogLevel LoggingLevel
+
+	toolsChangedPending bool
+	toolsChangedAt      time.Time
+	toolsChangedMu      sync.Mutex
 }
 
 func NewServer(name, version string) *Server {
 	return &Server{
-		name:         name,
-		version:      version,
-		tools:        make(map[string]Tool),
-		toolHandlers: make(map[string]ToolHandler),
+		name:               name,
+		version:            version,
+		tools:              make(map[string]Tool),
+		toolHandlers:       make(map[string]ToolHandler),
+		disabledTools:      make(map[string]bool),
+		resources:          make(map[string]Resource),
+		resourceHandlers:   make(map[string]ResourceHandler),
+		resourceWatchers:   make(map[string]ResourceWatcher),
+		resourceTools:      make(map[string]string),
+		prompts:            make(map[string]Prompt),
+		promptHandlers:     make(map[string]PromptHandler),
+		completionHandlers: make(map[string]CompletionHandler),
+		sessions:           make(map[string]*Session),
+		subscriptions:      make(map[string]*resourceSubscription),
+		inflight:           make(map[string]*inflightRequest),
+		toolsPageSize:      defaultToolsPageSize,
+		defaultLogLevel:    LoggingLevelInfo,
 	}
 }
 
@@ -48,9 +90,102 @@ func (s *Server) RegisterToolWithSchema(name, description string, inputSchema ma
 		InputSchema: inputSchema,
 	}
 	s.toolHandlers[name] = handler
+	s.toolsChanged()
+}
+
+// UnregisterTool removes a tool and reports whether it was registered.
+// Connected clients are told to refresh their tool list.
+func (s *Server) UnregisterTool(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tools[name]; !exists {
+		return false
+	}
+	delete(s.tools, name)
+	delete(s.toolHandlers, name)
+	delete(s.disabledTools, name)
+	s.toolsChanged()
+	return true
+}
+
+// SetToolEnabled hides a registered tool from tools/list and tools/call, or
+// brings it back, without losing its annotations, categories and completions.
+// Connected clients are told to refresh their tool list.
+func (s *Server) SetToolEnabled(name string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tools[name]; !exists {
+		return fmt.Errorf("tool not found: %s", name)
+	}
+	if s.disabledTools[name] == !enabled {
+		return nil
+	}
+	if enabled {
+		delete(s.disabledTools, name)
+	} else {
+		s.disabledTools[name] = true
+	}
+	s.toolsChanged()
+	return nil
 }
 
+// ToolNames returns the names of all registered tools in sorted order,
+// including disabled ones
+func (s *Server) ToolNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetToolAnnotations sets the display title and behavioral hints of a
+// registered tool
+func (s *Server) SetToolAnnotations(name, title string, annotations *ToolAnnotations) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tool, exists := s.tools[name]
+	if !exists {
+		return fmt.Errorf("tool not found: %s", name)
+	}
+	tool.Title = title
+	tool.Annotations = annotations
+	s.tools[name] = tool
+	s.toolsChanged()
+	return nil
+}
+
+// SetToolOutputSchema declares the JSON Schema that a registered tool's
+// structured result conforms to
+func (s *Server) SetToolOutputSchema(name string, outputSchema map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tool, exists := s.tools[name]
+	if !exists {
+		return fmt.Errorf("tool not found: %s", name)
+	}
+	tool.OutputSchema = outputSchema
+	s.tools[name] = tool
+	s.toolsChanged()
+	return nil
+}
+
+// HandleRequest dispatches a single JSON-RPC message. Notifications (messages
+// without an ID) are processed but produce no response, so nil is returned.
+// Nil is also returned for requests the client cancelled while in flight.
 func (s *Server) HandleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	if req.ID == nil {
+		s.handleNotification(ctx, req)
+		return nil
+	}
 	return s.handleRequest(ctx, req)
 }
 
@@ -59,6 +194,43 @@ func (s *Server) Serve(ctx context.Context, stdin io.Reader, stdout io.Writer) e
 	encoder := json.NewEncoder(stdout)
 	encoder.SetEscapeHTML(false)
 
+	// Notifications are written from watcher goroutines, so every write to
+	// stdout goes through the same lock
+	var writeMu sync.Mutex
+	write := func(v interface{}) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return encoder.Encode(v)
+	}
+
+	sess := s.NewSession(func(n *JSONRPCNotification) error {
+		return write(n)
+	})
+	// The stdio client is the local user running the server, so it also
+	// receives log entries that belong to no request
+	sess.serverLogs = true
+	defer s.CloseSession(sess.ID())
+	ctx = WithSession(ctx, sess)
+
+	// Tool calls run concurrently so that a slow call does not block the
+	// rest of the session; wait for them before tearing the session down
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+
+	respond := func(req *JSONRPCRequest) {
+		resp := s.handleRequest(ctx, req)
+		if resp == nil {
+			// Cancelled by the client, which no longer expects a response
+			return
+		}
+		// Ensure ID is always set in response
+		if resp.ID == nil {
+			resp.ID = req.ID
+		}
+		// Can't log to stderr in stdio mode, so write errors are dropped
+		write(resp)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -79,30 +251,43 @@ func (s *Server) Serve(ctx context.Context, stdin io.Reader, stdout io.Writer) e
 							Message: "Parse error",
 						},
 					}
-					encoder.Encode(errorResp)
+					write(errorResp)
 				}
 				continue
 			}
 
-			// Skip notifications (requests without ID)
+			// Notifications (requests without ID) never get a response
 			if req.ID == nil {
+				s.handleNotification(ctx, &req)
 				continue
 			}
 
-			resp := s.handleRequest(ctx, &req)
-			// Ensure ID is always set in response
-			if resp.ID == nil {
-				resp.ID = req.ID
-			}
-			if err := encoder.Encode(resp); err != nil {
-				// Can't log to stderr in stdio mode, just continue
+			if req.Method == "tools/call" {
+				inflight.Add(1)
+				go func() {
+					defer inflight.Done()
+					respond(&req)
+				}()
 				continue
 			}
+
+			respond(&req)
 		}
 	}
 }
 
+// handleRequest runs a request under a context that notifications/cancelled
+// can abort. It returns nil if the client cancelled the request.
 func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	ctx, finish := s.trackRequest(ctx, req.ID)
+	resp := s.routeRequest(ctx, req)
+	if finish() {
+		return nil
+	}
+	return resp
+}
+
+func (s *Server) routeRequest(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
 	// Ensure ID is always preserved
 	responseID := req.ID
 	if responseID == nil {
@@ -112,17 +297,53 @@ func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRP
 
 	switch req.Method {
 	case "initialize":
-		resp := s.handleInitialize(req)
+		resp := s.handleInitialize(ctx, req)
 		resp.ID = responseID
 		return resp
 	case "tools/list":
-		resp := s.handleToolsList(req)
+		resp := s.handleToolsList(ctx, req)
 		resp.ID = responseID
 		return resp
 	case "tools/call":
 		resp := s.handleToolsCall(ctx, req)
 		resp.ID = responseID
 		return resp
+	case "resources/list":
+		resp := s.handleResourcesList(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "resources/templates/list":
+		resp := s.handleResourceTemplatesList(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "resources/read":
+		resp := s.handleResourcesRead(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "resources/subscribe":
+		resp := s.handleResourcesSubscribe(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "resources/unsubscribe":
+		resp := s.handleResourcesUnsubscribe(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "prompts/list":
+		resp := s.handlePromptsList(req)
+		resp.ID = responseID
+		return resp
+	case "prompts/get":
+		resp := s.handlePromptsGet(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "completion/complete":
+		resp := s.handleCompletionComplete(ctx, req)
+		resp.ID = responseID
+		return resp
+	case "logging/setLevel":
+		resp := s.handleLoggingSetLevel(ctx, req)
+		resp.ID = responseID
+		return resp
 	default:
 		return &JSONRPCResponse{
 			JSONRPC: "2.0",
@@ -135,17 +356,56 @@ func (s *Server) handleRequest(ctx context.Context, req *JSONRPCRequest) *JSONRP
 	}
 }
 
-func (s *Server) handleInitialize(req *JSONRPCRequest) *JSONRPCResponse {
+// handleNotification processes a client notification. Unknown notifications
+// are ignored, as JSON-RPC does not allow replying to them.
+func (s *Server) handleNotification(ctx context.Context, req *JSONRPCRequest) {
+	switch req.Method {
+	case "notifications/initialized":
+		// Nothing to do; the session is usable as soon as initialize returns
+	case "notifications/cancelled":
+		s.handleCancelled(ctx, req)
+	}
+}
+
+func (s *Server) handleInitialize(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	var initReq InitializeRequest
+	if err := decodeParams(req.Params, &initReq); err != nil || initReq.ProtocolVersion == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: protocolVersion is required",
+			},
+		}
+	}
+
+	version := negotiateProtocolVersion(initReq.ProtocolVersion)
+	if sess, ok := SessionFromContext(ctx); ok {
+		sess.initialize(version, &initReq)
+	}
+
+	capabilities := ServerCapabilities{
+		Tools: &ToolsCapability{
+			ListChanged: true,
+		},
+		Resources: &ResourcesCapability{
+			Subscribe: true,
+		},
+		Prompts: &PromptsCapability{},
+		Logging: &LoggingCapability{},
+	}
+	// Completion was offered without a capability flag before 2025-03-26
+	if ProtocolVersionAtLeast(version, ProtocolVersion20250326) {
+		capabilities.Completions = &CompletionsCapability{}
+	}
+
 	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
 		Result: InitializeResponse{
-			ProtocolVersion: "2024-11-05",
-			Capabilities: ServerCapabilities{
-				Tools: &ToolsCapability{
-					ListChanged: true,
-				},
-			},
+			ProtocolVersion: version,
+			Capabilities:    capabilities,
 			ServerInfo: ServerInfo{
 				Name:    s.name,
 				Version: s.version,
@@ -154,22 +414,13 @@ func (s *Server) handleInitialize(req *JSONRPCRequest) *JSONRPCResponse {
 	}
 }
 
-func (s *Server) handleToolsList(req *JSONRPCRequest) *JSONRPCResponse {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	tools := make([]Tool, 0, len(s.tools))
-	for _, tool := range s.tools {
-		tools = append(tools, tool)
-	}
-
-	return &JSONRPCResponse{
-		JSONRPC: "2.0",
-		ID:      req.ID,
-		Result: ToolListResponse{
-			Tools: tools,
-		},
+// decodeParams converts generic request params into a typed struct
+func decodeParams(params map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
 	}
+	return json.Unmarshal(data, v)
 }
 
 func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
@@ -204,6 +455,9 @@ func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSON
 
 	s.mu.RLock()
 	handler, exists := s.toolHandlers[name]
+	exists = exists && !s.disabledTools[name]
+	tool := s.tools[name]
+	allowed := exists && s.toolAllowed(ctx, name)
 	s.mu.RUnlock()
 
 	if !exists {
@@ -216,6 +470,30 @@ func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSON
 			},
 		}
 	}
+	if !allowed {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32001,
+				Message: fmt.Sprintf("Forbidden: not permitted to call tool %s", name),
+			},
+		}
+	}
+
+	// Reject arguments that do not match the tool's schema before the
+	// handler sees them
+	if errs := validateArguments(tool.InputSchema, callReq.Arguments); len(errs) > 0 {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   invalidArgumentsError(name, errs),
+		}
+	}
+
+	if token, ok := progressToken(req.Params); ok {
+		ctx = withProgressToken(ctx, token)
+	}
 
 	result, err := handler(ctx, callReq.Arguments)
 	if err != nil {
@@ -226,7 +504,7 @@ func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSON
 				Content: []Content{
 					{
 						Type: "text",
-						Text: fmt.Sprintf("Error: %v", err),
+						Text: "Error: " + s.formatError(err),
 					},
 				},
 				IsError: true,
@@ -234,6 +512,22 @@ func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSON
 		}
 	}
 
+	if text, ok := result.(*TextResult); ok {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Result: CallToolResponse{
+				Content: []Content{
+					{
+						Type: "text",
+						Text: text.Text,
+					},
+				},
+				StructuredContent: structuredResult(ctx, text.Structured),
+			},
+		}
+	}
+
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		return &JSONRPCResponse{
@@ -256,6 +550,20 @@ func (s *Server) handleToolsCall(ctx context.Context, req *JSONRPCRequest) *JSON
 					Text: string(resultJSON),
 				},
 			},
+			StructuredContent: structuredResult(ctx, result),
 		},
 	}
 }
+
+// structuredResult returns the result for structuredContent, which must be a
+// JSON object. Other results, and all results for clients older than
+// 2025-06-18, are only sent as text.
+func structuredResult(ctx context.Context, result interface{}) interface{} {
+	if !ProtocolVersionAtLeast(protocolVersion(ctx), ProtocolVersion20250618) {
+		return nil
+	}
+	if obj, ok := result.(map[string]interface{}); ok && obj != nil {
+		return obj
+	}
+	return nil
+}
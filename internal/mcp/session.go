@@ -0,0 +1,223 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Session is a connected client. Transports create one per connection and
+// attach it to the request context so that handlers can push notifications
+// back to the client that issued the request.
+type Session struct {
+	id   string
+	send func(*JSONRPCNotification) error
+
+	mu                 sync.Mutex
+	closed             bool
+	subscriptions      map[string]string // URI to subscription key
+	protocolVersion    string
+	clientInfo         ClientInfo
+	clientCapabilities ClientCapabilities
+	initializedAt      time.Time
+	logLevel           LoggingLevel
+
+	// serverLogs is set on sessions that may see log entries unrelated to
+	// their own requests. It is set before the session is shared.
+	serverLogs bool
+}
+
+type sessionContextKey struct{}
+
+type requestNotifierContextKey struct{}
+
+// WithSession returns a copy of ctx carrying sess
+func WithSession(ctx context.Context, sess *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, sess)
+}
+
+// SessionFromContext returns the session attached to ctx, if any
+func SessionFromContext(ctx context.Context) (*Session, bool) {
+	sess, ok := ctx.Value(sessionContextKey{}).(*Session)
+	return sess, ok && sess != nil
+}
+
+// WithRequestNotifier returns a copy of ctx whose request-scoped
+// notifications, such as progress for a single call, are sent through send
+// instead of the session's shared stream
+func WithRequestNotifier(ctx context.Context, send func(*JSONRPCNotification) error) context.Context {
+	return context.WithValue(ctx, requestNotifierContextKey{}, send)
+}
+
+// Notify sends a notification related to the request in ctx. It prefers the
+// request's own channel and falls back to the session stream.
+func Notify(ctx context.Context, method string, params interface{}) error {
+	notification := &JSONRPCNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+	if send, ok := ctx.Value(requestNotifierContextKey{}).(func(*JSONRPCNotification) error); ok && send != nil {
+		return send(notification)
+	}
+	if sess, ok := SessionFromContext(ctx); ok {
+		return sess.send(notification)
+	}
+	return fmt.Errorf("no session to deliver %s", method)
+}
+
+// detachContext keeps the values of ctx for work that outlives the request,
+// but drops its cancellation along with the session and request notifier, so
+// that nothing is delivered to a request that has already completed
+func detachContext(ctx context.Context) context.Context {
+	ctx = context.WithoutCancel(ctx)
+	ctx = context.WithValue(ctx, sessionContextKey{}, (*Session)(nil))
+	return context.WithValue(ctx, requestNotifierContextKey{}, (func(*JSONRPCNotification) error)(nil))
+}
+
+// ID returns the session identifier
+func (sess *Session) ID() string {
+	return sess.id
+}
+
+// Notify sends a JSON-RPC notification to the client
+func (sess *Session) Notify(method string, params interface{}) error {
+	return sess.send(&JSONRPCNotification{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// ProtocolVersion returns the revision negotiated in initialize, or an empty
+// string before the session is initialized
+func (sess *Session) ProtocolVersion() string {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.protocolVersion
+}
+
+// ClientInfo returns the name and version the client sent in initialize
+func (sess *Session) ClientInfo() ClientInfo {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.clientInfo
+}
+
+// ClientCapabilities returns the capabilities the client declared in
+// initialize
+func (sess *Session) ClientCapabilities() ClientCapabilities {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.clientCapabilities
+}
+
+// SupportsRoots reports whether the client can answer roots/list
+func (sess *Session) SupportsRoots() bool {
+	return sess.ClientCapabilities().Roots != nil
+}
+
+// SupportsSampling reports whether the client can answer sampling/createMessage
+func (sess *Session) SupportsSampling() bool {
+	return sess.ClientCapabilities().Sampling != nil
+}
+
+// SupportsElicitation reports whether the client can answer
+// elicitation/create. Elicitation was introduced in 2025-06-18.
+func (sess *Session) SupportsElicitation() bool {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.clientCapabilities.Elicitation != nil &&
+		ProtocolVersionAtLeast(sess.protocolVersion, ProtocolVersion20250618)
+}
+
+// LogLevel returns the level selected with logging/setLevel, or an empty
+// level if the client has not chosen one
+func (sess *Session) LogLevel() LoggingLevel {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.logLevel
+}
+
+func (sess *Session) setLogLevel(level LoggingLevel) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.logLevel = level
+}
+
+// initialize records the outcome of the initialize handshake
+func (sess *Session) initialize(version string, req *InitializeRequest) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.protocolVersion = version
+	sess.clientInfo = req.ClientInfo
+	sess.clientCapabilities = req.Capabilities
+	sess.initializedAt = time.Now()
+}
+
+// initializedBefore reports whether the session completed initialize before t
+func (sess *Session) initializedBefore(t time.Time) bool {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.protocolVersion != "" && sess.initializedAt.Before(t)
+}
+
+// NewSession registers a new client session. send is called for every
+// server-initiated message and must be safe for concurrent use.
+func (s *Server) NewSession(send func(*JSONRPCNotification) error) *Session {
+	sess := &Session{
+		id:            newSessionID(),
+		send:          send,
+		subscriptions: make(map[string]string),
+	}
+
+	s.mu.Lock()
+	s.sessions[sess.id] = sess
+	s.mu.Unlock()
+
+	return sess
+}
+
+// Session looks up a registered session by ID
+func (s *Server) Session(id string) (*Session, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sess, ok := s.sessions[id]
+	return sess, ok
+}
+
+// CloseSession removes a session and drops all of its subscriptions
+func (s *Server) CloseSession(id string) {
+	s.mu.Lock()
+	sess, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// Subscriptions that start after this are refused
+	sess.mu.Lock()
+	sess.closed = true
+	uris := make([]string, 0, len(sess.subscriptions))
+	for uri := range sess.subscriptions {
+		uris = append(uris, uri)
+	}
+	sess.mu.Unlock()
+
+	for _, uri := range uris {
+		s.unsubscribe(sess, uri)
+	}
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate session ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
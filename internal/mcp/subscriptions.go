@@ -0,0 +1,291 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ResourceWatcher blocks until ctx is cancelled, calling notify whenever the
+// resource identified by uri changes. It is started when a caller first
+// subscribes to uri and cancelled when that caller's last session
+// unsubscribes.
+type ResourceWatcher func(ctx context.Context, uri string, params map[string]string, notify func()) error
+
+// resourceSubscription is one watcher and the sessions it notifies. Sessions
+// only share a watcher if they act as the same caller, because the watcher
+// runs with the credentials of the session that started it.
+type resourceSubscription struct {
+	uri      string
+	sessions map[string]*Session
+	cancel   context.CancelFunc
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of ctx acting as caller, an opaque key such as
+// the authenticated user and the Rancher token it uses. Requests without a
+// caller all act as the same one, like the local stdio client.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+func subscriptionKey(ctx context.Context, uri string) string {
+	caller, _ := ctx.Value(callerContextKey{}).(string)
+	return caller + " " + uri
+}
+
+// SetResourceWatcher enables subscriptions for a registered resource URI or
+// resource template
+func (s *Server) SetResourceWatcher(uriOrTemplate string, watcher ResourceWatcher) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.resources[uriOrTemplate]; exists {
+		s.resourceWatchers[uriOrTemplate] = watcher
+		return nil
+	}
+	for _, entry := range s.resourceTemplates {
+		if entry.template.URITemplate == uriOrTemplate {
+			entry.watcher = watcher
+			return nil
+		}
+	}
+	return fmt.Errorf("resource not registered: %s", uriOrTemplate)
+}
+
+// NotifyResourceUpdated sends notifications/resources/updated to every
+// session subscribed to uri
+func (s *Server) NotifyResourceUpdated(uri string) {
+	s.notifySubscribers(func(sub *resourceSubscription) bool { return sub.uri == uri })
+}
+
+func (s *Server) notifySubscribers(match func(sub *resourceSubscription) bool) {
+	type update struct {
+		sess *Session
+		uri  string
+	}
+	s.subMu.Lock()
+	var updates []update
+	for _, sub := range s.subscriptions {
+		if !match(sub) {
+			continue
+		}
+		for _, sess := range sub.sessions {
+			updates = append(updates, update{sess, sub.uri})
+		}
+	}
+	s.subMu.Unlock()
+
+	for _, u := range updates {
+		u.sess.Notify("notifications/resources/updated", ResourceUpdatedNotification{URI: u.uri})
+	}
+}
+
+// matchWatcher finds the watcher responsible for uri
+func (s *Server) matchWatcher(uri string) (ResourceWatcher, map[string]string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if watcher, exists := s.resourceWatchers[uri]; exists {
+		return watcher, map[string]string{}, true
+	}
+
+	for _, entry := range s.resourceTemplates {
+		if entry.watcher == nil {
+			continue
+		}
+		matches := entry.pattern.FindStringSubmatch(uri)
+		if matches == nil {
+			continue
+		}
+		params := make(map[string]string, len(entry.vars))
+		for i, name := range entry.vars {
+			params[name] = matches[i+1]
+		}
+		return entry.watcher, params, true
+	}
+
+	return nil, nil, false
+}
+
+// subscribe adds sess as a subscriber to uri, starting a watcher if this is
+// the caller's first subscription to it. The resource is read first, so that
+// a caller cannot subscribe to what it may not read. The watcher inherits the
+// values of ctx, such as the caller's credentials, but outlives the request.
+func (s *Server) subscribe(ctx context.Context, sess *Session, uri string) error {
+	watcher, params, exists := s.matchWatcher(uri)
+	if !exists {
+		return fmt.Errorf("resource does not support subscriptions: %s", uri)
+	}
+	if !s.resourceURIAllowed(ctx, uri) {
+		return errForbiddenResource
+	}
+	if match, exists := s.matchResource(uri); exists {
+		if _, err := match.handler(ctx, uri, match.params); err != nil {
+			return &subscribeReadError{uri: uri, err: err}
+		}
+	}
+
+	key := subscriptionKey(ctx, uri)
+
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	// The session may have closed while the resource was read. Checking
+	// under its lock keeps CloseSession from missing the subscription and
+	// leaving the watcher running.
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.closed {
+		return errSessionClosed
+	}
+
+	sub, exists := s.subscriptions[key]
+	if !exists {
+		ctx, cancel := context.WithCancel(detachContext(ctx))
+		sub = &resourceSubscription{
+			uri:      uri,
+			sessions: make(map[string]*Session),
+			cancel:   cancel,
+		}
+		s.subscriptions[key] = sub
+		go watcher(ctx, uri, params, func() {
+			s.notifySubscribers(func(other *resourceSubscription) bool { return other == sub })
+		})
+	}
+	sub.sessions[sess.id] = sess
+	sess.subscriptions[uri] = key
+	return nil
+}
+
+// errSessionClosed is returned when the session closed during subscribe
+var errSessionClosed = errors.New("session closed")
+
+// errForbiddenResource is returned when the subscriber may not use the
+// resource at all
+var errForbiddenResource = errors.New("forbidden")
+
+// subscribeReadError is returned when the subscriber cannot read the resource
+type subscribeReadError struct {
+	uri string
+	err error
+}
+
+func (e *subscribeReadError) Error() string {
+	return fmt.Sprintf("Failed to read resource %s: %v", e.uri, e.err)
+}
+
+func (e *subscribeReadError) Unwrap() error {
+	return e.err
+}
+
+func (s *Server) unsubscribe(sess *Session, uri string) {
+	sess.mu.Lock()
+	key, subscribed := sess.subscriptions[uri]
+	delete(sess.subscriptions, uri)
+	sess.mu.Unlock()
+	if !subscribed {
+		return
+	}
+
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	sub, exists := s.subscriptions[key]
+	if !exists {
+		return
+	}
+	delete(sub.sessions, sess.id)
+	if len(sub.sessions) == 0 {
+		sub.cancel()
+		delete(s.subscriptions, key)
+	}
+}
+
+func (s *Server) handleResourcesSubscribe(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	uri, ok := req.Params["uri"].(string)
+	if !ok || uri == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: uri is required",
+			},
+		}
+	}
+
+	sess, ok := SessionFromContext(ctx)
+	if !ok {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32600,
+				Message: "Subscriptions require a session-capable transport",
+			},
+		}
+	}
+
+	if err := s.subscribe(ctx, sess, uri); err != nil {
+		if err == errForbiddenResource {
+			return &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   forbiddenResourceError(uri),
+			}
+		}
+		var readErr *subscribeReadError
+		if errors.As(err, &readErr) {
+			return &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &JSONRPCError{
+					Code:    -32603,
+					Message: fmt.Sprintf("Failed to read resource %s: %s", uri, s.formatError(readErr.err)),
+					Data:    map[string]interface{}{"uri": uri},
+				},
+			}
+		}
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: err.Error(),
+				Data:    map[string]interface{}{"uri": uri},
+			},
+		}
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  struct{}{},
+	}
+}
+
+func (s *Server) handleResourcesUnsubscribe(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	uri, ok := req.Params["uri"].(string)
+	if !ok || uri == "" {
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error: &JSONRPCError{
+				Code:    -32602,
+				Message: "Invalid params: uri is required",
+			},
+		}
+	}
+
+	if sess, ok := SessionFromContext(ctx); ok {
+		s.unsubscribe(sess, uri)
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  struct{}{},
+	}
+}
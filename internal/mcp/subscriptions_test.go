@@ -0,0 +1,264 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+type callerKey struct{}
+
+// watchRecorder registers a resource whose reads are refused for the caller
+// "denied" and whose watchers record the caller they run as
+type watchRecorder struct {
+	mu       sync.Mutex
+	callers  []string
+	notifies map[string]func()
+	stopped  chan string
+}
+
+func newWatchedServer(t *testing.T) (*Server, *watchRecorder) {
+	t.Helper()
+	s := NewServer("test", "1")
+	w := &watchRecorder{notifies: make(map[string]func()), stopped: make(chan string, 4)}
+
+	s.RegisterResource(Resource{URI: "test://things", Name: "things"}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		if ctx.Value(callerKey{}) == "denied" {
+			return nil, errors.New("forbidden")
+		}
+		return []string{}, nil
+	})
+	err := s.SetResourceWatcher("test://things", func(ctx context.Context, uri string, params map[string]string, notify func()) error {
+		caller, _ := ctx.Value(callerKey{}).(string)
+		w.mu.Lock()
+		w.callers = append(w.callers, caller)
+		w.notifies[caller] = notify
+		w.mu.Unlock()
+		<-ctx.Done()
+		w.stopped <- caller
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SetResourceWatcher failed: %v", err)
+	}
+	return s, w
+}
+
+// watchers returns the callers of the watchers started so far, once count
+// have started
+func (w *watchRecorder) watchers(t *testing.T, count int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		w.mu.Lock()
+		callers := append([]string(nil), w.callers...)
+		w.mu.Unlock()
+		if len(callers) >= count || time.Now().After(deadline) {
+			return callers
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// subscribeAs subscribes the session to test://things acting as caller
+func subscribeAs(s *Server, sess *testSession, caller string) *JSONRPCResponse {
+	ctx := WithCaller(WithSession(context.WithValue(context.Background(), callerKey{}, caller), sess.Session), caller)
+	return s.HandleRequest(ctx, &JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "resources/subscribe",
+		Params:  map[string]interface{}{"uri": "test://things"},
+	})
+}
+
+func TestSubscriptionsAreSeparatedByCaller(t *testing.T) {
+	s, w := newWatchedServer(t)
+	alice1 := newTestSession(t, s, ProtocolVersion20250618)
+	alice2 := newTestSession(t, s, ProtocolVersion20250618)
+	bob := newTestSession(t, s, ProtocolVersion20250618)
+
+	for _, sub := range []struct {
+		sess   *testSession
+		caller string
+	}{{alice1, "alice"}, {alice2, "alice"}, {bob, "bob"}} {
+		if resp := subscribeAs(s, sub.sess, sub.caller); resp.Error != nil {
+			t.Fatalf("subscribe as %s failed: %s", sub.caller, resp.Error.Message)
+		}
+	}
+
+	// Sessions of the same caller share a watcher, and each watcher runs as
+	// the caller that started it
+	callers := w.watchers(t, 2)
+	sort.Strings(callers)
+	if len(callers) != 2 || callers[0] != "alice" || callers[1] != "bob" {
+		t.Fatalf("watchers run as %v, want [alice bob]", callers)
+	}
+
+	w.mu.Lock()
+	notify := w.notifies["bob"]
+	w.mu.Unlock()
+	notify()
+	bob.waitFor(t, "notifications/resources/updated")
+	alice1.expectNone(t, "notifications/resources/updated", 20*time.Millisecond)
+	alice2.expectNone(t, "notifications/resources/updated", 0)
+
+	// A caller's watcher stops when its last session unsubscribes
+	s.unsubscribe(alice1.Session, "test://things")
+	select {
+	case caller := <-w.stopped:
+		t.Fatalf("watcher of %s stopped while alice2 is still subscribed", caller)
+	case <-time.After(20 * time.Millisecond):
+	}
+	s.CloseSession(alice2.ID())
+	select {
+	case caller := <-w.stopped:
+		if caller != "alice" {
+			t.Errorf("watcher of %s stopped, want alice", caller)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher did not stop after the last session left")
+	}
+}
+
+func TestSubscribeRequiresRead(t *testing.T) {
+	s, w := newWatchedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+	if resp := subscribeAs(s, newTestSession(t, s, ProtocolVersion20250618), "alice"); resp.Error != nil {
+		t.Fatalf("subscribe as alice failed: %s", resp.Error.Message)
+	}
+
+	resp := subscribeAs(s, sess, "denied")
+	if resp.Error == nil || resp.Error.Code != -32603 {
+		t.Fatalf("subscribe without read access = %+v, want -32603", resp.Error)
+	}
+	if callers := w.watchers(t, 1); len(callers) != 1 {
+		t.Errorf("watchers run as %v, want only alice", callers)
+	}
+	s.NotifyResourceUpdated("test://things")
+	sess.expectNone(t, "notifications/resources/updated", 20*time.Millisecond)
+}
+
+func TestSubscribeUnknownResource(t *testing.T) {
+	s, _ := newWatchedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	resp := sess.call(s, "resources/subscribe", map[string]interface{}{"uri": "test://other"})
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Errorf("subscribe to an unknown resource = %+v, want -32602", resp.Error)
+	}
+}
+
+func TestSubscribeTemplateParams(t *testing.T) {
+	s := NewServer("test", "1")
+	params := make(chan map[string]string, 1)
+	err := s.RegisterResourceTemplate(ResourceTemplate{URITemplate: "test://things/{namespace}/{name}", Name: "thing"}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		return map[string]interface{}{}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterResourceTemplate failed: %v", err)
+	}
+	err = s.SetResourceWatcher("test://things/{namespace}/{name}", func(ctx context.Context, uri string, p map[string]string, notify func()) error {
+		params <- p
+		<-ctx.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SetResourceWatcher failed: %v", err)
+	}
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	if resp := sess.call(s, "resources/subscribe", map[string]interface{}{"uri": "test://things/c-1/p-1"}); resp.Error != nil {
+		t.Fatalf("subscribe failed: %s", resp.Error.Message)
+	}
+	select {
+	case p := <-params:
+		if p["namespace"] != "c-1" || p["name"] != "p-1" {
+			t.Errorf("watcher params = %v, want namespace c-1 and name p-1", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("watcher was not started")
+	}
+}
+
+func TestSubscribeWithoutSession(t *testing.T) {
+	s, w := newWatchedServer(t)
+	resp := s.HandleRequest(context.Background(), &JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "resources/subscribe",
+		Params:  map[string]interface{}{"uri": "test://things"},
+	})
+	if resp.Error == nil || resp.Error.Code != -32600 {
+		t.Errorf("subscribe without a session = %+v, want -32600", resp.Error)
+	}
+	if callers := w.watchers(t, 0); len(callers) != 0 {
+		t.Errorf("watchers run as %v, want none", callers)
+	}
+}
+
+func TestUnsubscribeWithoutSubscription(t *testing.T) {
+	s, w := newWatchedServer(t)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+	other := newTestSession(t, s, ProtocolVersion20250618)
+	if resp := subscribeAs(s, other, ""); resp.Error != nil {
+		t.Fatalf("subscribe failed: %s", resp.Error.Message)
+	}
+	w.watchers(t, 1)
+
+	// Unsubscribing a session that never subscribed leaves the shared
+	// watcher of the caller running
+	if resp := sess.call(s, "resources/unsubscribe", map[string]interface{}{"uri": "test://things"}); resp.Error != nil {
+		t.Fatalf("unsubscribe failed: %s", resp.Error.Message)
+	}
+	select {
+	case caller := <-w.stopped:
+		t.Fatalf("watcher of %q stopped", caller)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestSubscribeRacingCloseSession(t *testing.T) {
+	s, _ := newWatchedServer(t)
+	reading, release := make(chan struct{}), make(chan struct{})
+	s.RegisterResource(Resource{URI: "test://slow", Name: "slow"}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		close(reading)
+		<-release
+		return []string{}, nil
+	})
+	started := make(chan struct{}, 1)
+	err := s.SetResourceWatcher("test://slow", func(ctx context.Context, uri string, params map[string]string, notify func()) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SetResourceWatcher failed: %v", err)
+	}
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	// The session closes while subscribe is reading the resource
+	responses := make(chan *JSONRPCResponse, 1)
+	go func() {
+		responses <- sess.call(s, "resources/subscribe", map[string]interface{}{"uri": "test://slow"})
+	}()
+	<-reading
+	s.CloseSession(sess.ID())
+	close(release)
+
+	if resp := <-responses; resp.Error == nil {
+		t.Error("subscribe on a closed session succeeded")
+	}
+	select {
+	case <-started:
+		t.Error("a watcher was started for a closed session")
+	case <-time.After(20 * time.Millisecond):
+	}
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	if len(s.subscriptions) != 0 {
+		t.Errorf("subscriptions = %v, want none", s.subscriptions)
+	}
+}
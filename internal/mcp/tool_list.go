@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+)
+
+const (
+	defaultToolsPageSize = 100
+
+	// toolCategoriesMetaKey is the Tool _meta key holding its categories
+	toolCategoriesMetaKey = "categories"
+)
+
+// SetToolsPageSize sets how many tools a single tools/list page returns.
+// Values below one disable pagination.
+func (s *Server) SetToolsPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.toolsPageSize = size
+}
+
+// SetToolCategories tags a registered tool with categories that tools/list
+// can filter on. They are published in the tool's _meta.
+func (s *Server) SetToolCategories(name string, categories ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tool, exists := s.tools[name]
+	if !exists {
+		return fmt.Errorf("tool not found: %s", name)
+	}
+	meta := make(map[string]interface{}, len(tool.Meta)+1)
+	for k, v := range tool.Meta {
+		meta[k] = v
+	}
+	meta[toolCategoriesMetaKey] = append([]string(nil), categories...)
+	tool.Meta = meta
+	s.tools[name] = tool
+	s.toolsChanged()
+	return nil
+}
+
+// toolHasCategory reports whether tool is tagged with category
+func toolHasCategory(tool Tool, category string) bool {
+	categories, _ := tool.Meta[toolCategoriesMetaKey].([]string)
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeToolsCursor and decodeToolsCursor wrap the name of the last tool on a
+// page. Tools are sorted by name, so the next page starts after it even if
+// tools were added or removed in between.
+func encodeToolsCursor(lastName string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastName))
+}
+
+func decodeToolsCursor(cursor string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(decoded) == 0 {
+		return "", fmt.Errorf("invalid cursor")
+	}
+	return string(decoded), nil
+}
+
+// handleToolsList returns tools sorted by name, one page at a time. The
+// optional non-standard "category" param restricts the list to tools tagged
+// with that category.
+func (s *Server) handleToolsList(ctx context.Context, req *JSONRPCRequest) *JSONRPCResponse {
+	var after string
+	if cursor, ok := req.Params["cursor"].(string); ok && cursor != "" {
+		name, err := decodeToolsCursor(cursor)
+		if err != nil {
+			return &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error: &JSONRPCError{
+					Code:    -32602,
+					Message: "Invalid params: invalid cursor",
+				},
+			}
+		}
+		after = name
+	}
+	category, _ := req.Params["category"].(string)
+
+	s.mu.RLock()
+	names := make([]string, 0, len(s.tools))
+	for name, tool := range s.tools {
+		if after != "" && name <= after {
+			continue
+		}
+		if s.disabledTools[name] {
+			continue
+		}
+		if category != "" && !toolHasCategory(tool, category) {
+			continue
+		}
+		if !s.toolAllowed(ctx, name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pageSize := s.toolsPageSize
+	var nextCursor string
+	if pageSize > 0 && len(names) > pageSize {
+		names = names[:pageSize]
+		nextCursor = encodeToolsCursor(names[len(names)-1])
+	}
+
+	version := protocolVersion(ctx)
+	tools := make([]Tool, 0, len(names))
+	for _, name := range names {
+		tools = append(tools, toolForVersion(s.tools[name], version))
+	}
+	s.mu.RUnlock()
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: ToolListResponse{
+			Tools:      tools,
+			NextCursor: nextCursor,
+		},
+	}
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newToolListServer registers count tools named tool-00, tool-01, ... whose
+// category is "even" or "odd"
+func newToolListServer(count, pageSize int) *Server {
+	s := NewServer("test", "1")
+	s.SetToolsPageSize(pageSize)
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("tool-%02d", i)
+		s.RegisterTool(name, name, noopTool)
+		category := "even"
+		if i%2 == 1 {
+			category = "odd"
+		}
+		s.SetToolCategories(name, category)
+	}
+	return s
+}
+
+func toolNames(tools []Tool) []string {
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	return names
+}
+
+func TestToolsListPagination(t *testing.T) {
+	s := newToolListServer(7, 3)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	var pages [][]string
+	params := map[string]interface{}{}
+	for {
+		resp := sess.call(s, "tools/list", params)
+		if resp.Error != nil {
+			t.Fatalf("tools/list failed: %s", resp.Error.Message)
+		}
+		list := resp.Result.(ToolListResponse)
+		pages = append(pages, toolNames(list.Tools))
+		if list.NextCursor == "" {
+			break
+		}
+		if len(pages) > 10 {
+			t.Fatal("pagination does not terminate")
+		}
+		params = map[string]interface{}{"cursor": list.NextCursor}
+	}
+
+	want := "[[tool-00 tool-01 tool-02] [tool-03 tool-04 tool-05] [tool-06]]"
+	if got := fmt.Sprint(pages); got != want {
+		t.Errorf("pages = %s, want %s", got, want)
+	}
+}
+
+func TestToolsListCursorSurvivesChanges(t *testing.T) {
+	s := newToolListServer(4, 2)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	first := sess.call(s, "tools/list", nil).Result.(ToolListResponse)
+	// Removing a listed tool and adding one before the cursor must not shift
+	// the next page
+	s.UnregisterTool("tool-00")
+	s.RegisterTool("tool-000", "tool-000", noopTool)
+
+	next := sess.call(s, "tools/list", map[string]interface{}{"cursor": first.NextCursor}).Result.(ToolListResponse)
+	if got := fmt.Sprint(toolNames(next.Tools)); got != "[tool-02 tool-03]" {
+		t.Errorf("second page = %s, want [tool-02 tool-03]", got)
+	}
+}
+
+func TestToolsListInvalidCursor(t *testing.T) {
+	s := newToolListServer(3, 2)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	for _, cursor := range []string{"not base64!", "===="} {
+		resp := sess.call(s, "tools/list", map[string]interface{}{"cursor": cursor})
+		if resp.Error == nil || resp.Error.Code != -32602 {
+			t.Errorf("tools/list with cursor %q = %+v, want -32602", cursor, resp.Error)
+		}
+	}
+}
+
+func TestToolsListPaginationDisabled(t *testing.T) {
+	s := newToolListServer(5, 0)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	list := sess.call(s, "tools/list", nil).Result.(ToolListResponse)
+	if len(list.Tools) != 5 || list.NextCursor != "" {
+		t.Errorf("tools/list = %d tools and cursor %q, want all 5 tools on one page", len(list.Tools), list.NextCursor)
+	}
+}
+
+func TestToolsListCategory(t *testing.T) {
+	s := newToolListServer(7, 2)
+	sess := newTestSession(t, s, ProtocolVersion20250618)
+
+	first := sess.call(s, "tools/list", map[string]interface{}{"category": "odd"}).Result.(ToolListResponse)
+	if got := fmt.Sprint(toolNames(first.Tools)); got != "[tool-01 tool-03]" {
+		t.Errorf("odd tools = %s, want [tool-01 tool-03]", got)
+	}
+	// The cursor of a filtered page continues the filtered list
+	next := sess.call(s, "tools/list", map[string]interface{}{"category": "odd", "cursor": first.NextCursor}).Result.(ToolListResponse)
+	if got := fmt.Sprint(toolNames(next.Tools)); got != "[tool-05]" || next.NextCursor != "" {
+		t.Errorf("second page of odd tools = %s, want [tool-05] and no cursor", got)
+	}
+
+	none := sess.call(s, "tools/list", map[string]interface{}{"category": "missing"}).Result.(ToolListResponse)
+	if len(none.Tools) != 0 {
+		t.Errorf("tools in an unknown category = %s, want none", toolNames(none.Tools))
+	}
+}
+
+func TestToolForVersion(t *testing.T) {
+	tool := Tool{
+		Name:         "get_cluster",
+		Title:        "Get Cluster",
+		Annotations:  &ToolAnnotations{Title: "Get Cluster"},
+		OutputSchema: map[string]interface{}{"type": "object"},
+		Meta:         map[string]interface{}{toolCategoriesMetaKey: []string{"cluster"}},
+	}
+
+	tests := []struct {
+		version         string
+		wantTitle       bool
+		wantAnnotations bool
+	}{
+		{ProtocolVersion20250618, true, true},
+		{ProtocolVersion20250326, false, true},
+		{ProtocolVersion20241105, false, false},
+	}
+	for _, tt := range tests {
+		got := toolForVersion(tool, tt.version)
+		if (got.Title != "") != tt.wantTitle || (got.OutputSchema != nil) != tt.wantTitle || (got.Meta != nil) != tt.wantTitle {
+			t.Errorf("%s: title, output schema and _meta present = %v, want %v", tt.version, got.Title != "", tt.wantTitle)
+		}
+		if (got.Annotations != nil) != tt.wantAnnotations {
+			t.Errorf("%s: annotations present = %v, want %v", tt.version, got.Annotations != nil, tt.wantAnnotations)
+		}
+	}
+	if tool.Title == "" || tool.Annotations == nil {
+		t.Error("toolForVersion modified the registered tool")
+	}
+}
+
+func TestToolsListStripsFieldsForOlderClients(t *testing.T) {
+	s := newToolListServer(1, 0)
+	sess := newTestSession(t, s, ProtocolVersion20241105)
+
+	list := sess.call(s, "tools/list", nil).Result.(ToolListResponse)
+	if len(list.Tools) != 1 || list.Tools[0].Meta != nil {
+		t.Errorf("tools/list for %s = %+v, want the tool without _meta", ProtocolVersion20241105, list.Tools)
+	}
+}
@@ -15,6 +15,12 @@ type JSONRPCResponse struct {
 	Error   *JSONRPCError `json:"error,omitempty"`
 }
 
+type JSONRPCNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
 type JSONRPCError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
@@ -23,9 +29,22 @@ type JSONRPCError struct {
 
 // MCP specific types
 type InitializeRequest struct {
-	ProtocolVersion string                 `json:"protocolVersion"`
-	Capabilities    map[string]interface{} `json:"capabilities"`
-	ClientInfo      ClientInfo             `json:"clientInfo"`
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      ClientInfo         `json:"clientInfo"`
+}
+
+// ClientCapabilities are the optional features a client declares in
+// initialize. A nil field means the client does not support the feature.
+type ClientCapabilities struct {
+	Roots        *RootsCapability       `json:"roots,omitempty"`
+	Sampling     map[string]interface{} `json:"sampling,omitempty"`
+	Elicitation  map[string]interface{} `json:"elicitation,omitempty"`
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+}
+
+type RootsCapability struct {
+	ListChanged bool `json:"listChanged"`
 }
 
 type ClientInfo struct {
@@ -40,8 +59,11 @@ type InitializeResponse struct {
 }
 
 type ServerCapabilities struct {
-	Tools     *ToolsCapability     `json:"tools,omitempty"`
-	Resources *ResourcesCapability `json:"resources,omitempty"`
+	Tools       *ToolsCapability       `json:"tools,omitempty"`
+	Resources   *ResourcesCapability   `json:"resources,omitempty"`
+	Prompts     *PromptsCapability     `json:"prompts,omitempty"`
+	Logging     *LoggingCapability     `json:"logging,omitempty"`
+	Completions *CompletionsCapability `json:"completions,omitempty"`
 }
 
 type ToolsCapability struct {
@@ -53,19 +75,43 @@ type ResourcesCapability struct {
 	ListChanged bool `json:"listChanged"`
 }
 
+type PromptsCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
+type LoggingCapability struct{}
+
+type CompletionsCapability struct{}
+
 type ServerInfo struct {
 	Name    string `json:"name"`
 	Version string `json:"version"`
 }
 
 type Tool struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	InputSchema map[string]interface{} `json:"inputSchema"`
+	Name         string                 `json:"name"`
+	Title        string                 `json:"title,omitempty"`
+	Description  string                 `json:"description"`
+	InputSchema  map[string]interface{} `json:"inputSchema"`
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations       `json:"annotations,omitempty"`
+	Meta         map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// ToolAnnotations are behavioral hints that clients use to decide, for
+// example, whether a call needs user confirmation. Hints are pointers so
+// that an explicit false is distinguishable from unset.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
 }
 
 type ToolListResponse struct {
-	Tools []Tool `json:"tools"`
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type CallToolRequest struct {
@@ -73,12 +119,110 @@ type CallToolRequest struct {
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
 }
 
+// CallToolResponse carries a tool result. StructuredContent holds the result
+// as a JSON object for clients that understand it; Content always carries the
+// same result serialized as text for older clients.
 type CallToolResponse struct {
-	Content []Content `json:"content"`
-	IsError bool      `json:"isError,omitempty"`
+	Content           []Content   `json:"content"`
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+	IsError           bool        `json:"isError,omitempty"`
+}
+
+// TextResult lets a tool handler choose the text content of its result
+// instead of having the result serialized as JSON. Structured is still sent
+// as structuredContent when it is an object, so that it matches the tool's
+// output schema.
+type TextResult struct {
+	Text       string
+	Structured interface{}
 }
 
 type Content struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
 }
+
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+type ResourceListResponse struct {
+	Resources []Resource `json:"resources"`
+}
+
+type ResourceTemplateListResponse struct {
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+}
+
+type ReadResourceResponse struct {
+	Contents []ResourceContents `json:"contents"`
+}
+
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text"`
+}
+
+type ResourceUpdatedNotification struct {
+	URI string `json:"uri"`
+}
+
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+type PromptListResponse struct {
+	Prompts []Prompt `json:"prompts"`
+}
+
+type GetPromptResponse struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+type PromptMessage struct {
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+type ProgressNotification struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
+}
+
+type LoggingMessageNotification struct {
+	Level  LoggingLevel `json:"level"`
+	Logger string       `json:"logger,omitempty"`
+	Data   interface{}  `json:"data"`
+}
+
+type CompleteResponse struct {
+	Completion CompletionResult `json:"completion"`
+}
+
+type CompletionResult struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total,omitempty"`
+	HasMore bool     `json:"hasMore,omitempty"`
+}
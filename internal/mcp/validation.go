@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldError describes one argument that does not satisfy a tool's input schema
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var (
+	patternCache   = make(map[string]*regexp.Regexp)
+	patternCacheMu sync.Mutex
+)
+
+// validateArguments checks args against a JSON Schema. It supports the subset
+// of keywords used by tool schemas: type, required, properties,
+// additionalProperties, items, enum and pattern.
+func validateArguments(schema map[string]interface{}, args map[string]interface{}) []FieldError {
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	var errs []FieldError
+	validateValue(schema, args, "", &errs)
+	return errs
+}
+
+func validateValue(schema map[string]interface{}, value interface{}, path string, errs *[]FieldError) {
+	if schema == nil {
+		return
+	}
+
+	field := path
+	if field == "" {
+		field = "arguments"
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		if !typeAllowed(types, actual) {
+			*errs = append(*errs, FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), actual),
+			})
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		allowed := toInterfaceSlice(enum)
+		found := false
+		for _, candidate := range allowed {
+			if enumEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(allowed))
+			for _, candidate := range allowed {
+				names = append(names, fmt.Sprintf("%v", candidate))
+			}
+			*errs = append(*errs, FieldError{
+				Field:   field,
+				Message: fmt.Sprintf("must be one of: %s", strings.Join(names, ", ")),
+			})
+		}
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		if str, isString := value.(string); isString {
+			re, err := compilePattern(pattern)
+			if err == nil && !re.MatchString(str) {
+				*errs = append(*errs, FieldError{
+					Field:   field,
+					Message: fmt.Sprintf("must match pattern %s", pattern),
+				})
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		validateObject(schema, v, path, errs)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", field, i), errs)
+			}
+		}
+	}
+}
+
+func validateObject(schema map[string]interface{}, obj map[string]interface{}, path string, errs *[]FieldError) {
+	for _, name := range toStringSlice(schema["required"]) {
+		if value, exists := obj[name]; !exists || value == nil {
+			*errs = append(*errs, FieldError{
+				Field:   joinField(path, name),
+				Message: "is required",
+			})
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	// Sort keys so errors come back in a stable order
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propSchema, known := properties[key].(map[string]interface{})
+		if !known {
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				*errs = append(*errs, FieldError{
+					Field:   joinField(path, key),
+					Message: "is not a recognized argument",
+				})
+			}
+			continue
+		}
+		if obj[key] == nil {
+			continue
+		}
+		validateValue(propSchema, obj[key], joinField(path, key), errs)
+	}
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case int, int32, int64:
+		return "integer"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// enumEqual reports whether value is the enum candidate as JSON, so that the
+// string "1" does not match the number 1. Go integers in schemas compare equal
+// to the float64 numbers decoded from arguments.
+func enumEqual(candidate, value interface{}) bool {
+	return reflect.DeepEqual(jsonNumber(candidate), jsonNumber(value))
+}
+
+func jsonNumber(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return value
+	}
+}
+
+func typeAllowed(types []string, actual string) bool {
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaTypes(raw interface{}) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	default:
+		return toStringSlice(raw)
+	}
+}
+
+func toStringSlice(raw interface{}) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func toInterfaceSlice(raw interface{}) []interface{} {
+	switch v := raw.(type) {
+	case []interface{}:
+		return v
+	case []string:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = item
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	patternCacheMu.Lock()
+	defer patternCacheMu.Unlock()
+
+	if re, ok := patternCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache[pattern] = re
+	return re, nil
+}
+
+// invalidArgumentsError builds the -32602 error returned for schema violations
+func invalidArgumentsError(tool string, errs []FieldError) *JSONRPCError {
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		parts = append(parts, fmt.Sprintf("%s %s", e.Field, e.Message))
+	}
+	return &JSONRPCError{
+		Code:    -32602,
+		Message: fmt.Sprintf("Invalid arguments for tool %s: %s", tool, strings.Join(parts, "; ")),
+		Data: map[string]interface{}{
+			"tool":   tool,
+			"errors": errs,
+		},
+	}
+}
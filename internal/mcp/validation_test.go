@@ -0,0 +1,186 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// decodeArgs decodes arguments the way tools/call receives them
+func decodeArgs(t *testing.T, raw string) map[string]interface{} {
+	t.Helper()
+	var args map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &args); err != nil {
+		t.Fatalf("invalid test arguments %s: %v", raw, err)
+	}
+	return args
+}
+
+func TestValidateArguments(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":     map[string]interface{}{"type": "string", "pattern": "^[a-z0-9-]+$"},
+			"replicas": map[string]interface{}{"type": "integer", "enum": []interface{}{1, 3, 5}},
+			"scale":    map[string]interface{}{"type": "number"},
+			"mode":     map[string]interface{}{"type": "string", "enum": []string{"fast", "safe"}},
+			"patch":    map[string]interface{}{"type": []string{"object", "array"}},
+			"labels": map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{"team": map[string]interface{}{"type": "string"}},
+				"required":             []string{"team"},
+				"additionalProperties": false,
+			},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+		"required":             []string{"name"},
+		"additionalProperties": false,
+	}
+
+	tests := []struct {
+		name string
+		args string
+		want []FieldError
+	}{
+		{
+			name: "valid",
+			args: `{"name":"c-1","replicas":3,"scale":1.5,"mode":"safe","patch":[],"labels":{"team":"a"},"tags":["x"]}`,
+		},
+		{
+			name: "missing required",
+			args: `{}`,
+			want: []FieldError{{Field: "name", Message: "is required"}},
+		},
+		{
+			name: "null required",
+			args: `{"name":null}`,
+			want: []FieldError{{Field: "name", Message: "is required"}},
+		},
+		{
+			name: "wrong type",
+			args: `{"name":1}`,
+			want: []FieldError{{Field: "name", Message: "expected string, got integer"}},
+		},
+		{
+			name: "number for integer",
+			args: `{"name":"c","replicas":1.5}`,
+			want: []FieldError{{Field: "replicas", Message: "expected integer, got number"}},
+		},
+		{
+			name: "integer for number",
+			args: `{"name":"c","scale":2}`,
+		},
+		{
+			name: "one of several types",
+			args: `{"name":"c","patch":"x"}`,
+			want: []FieldError{{Field: "patch", Message: "expected object or array, got string"}},
+		},
+		{
+			name: "integer enum",
+			args: `{"name":"c","replicas":2}`,
+			want: []FieldError{{Field: "replicas", Message: "must be one of: 1, 3, 5"}},
+		},
+		{
+			name: "string enum",
+			args: `{"name":"c","mode":"slow"}`,
+			want: []FieldError{{Field: "mode", Message: "must be one of: fast, safe"}},
+		},
+		{
+			name: "pattern",
+			args: `{"name":"C_1"}`,
+			want: []FieldError{{Field: "name", Message: "must match pattern ^[a-z0-9-]+$"}},
+		},
+		{
+			name: "unknown argument",
+			args: `{"name":"c","nmae":"c"}`,
+			want: []FieldError{{Field: "nmae", Message: "is not a recognized argument"}},
+		},
+		{
+			name: "nested object",
+			args: `{"name":"c","labels":{"owner":"b"}}`,
+			want: []FieldError{
+				{Field: "labels.team", Message: "is required"},
+				{Field: "labels.owner", Message: "is not a recognized argument"},
+			},
+		},
+		{
+			name: "items",
+			args: `{"name":"c","tags":["x",2,"y",true]}`,
+			want: []FieldError{
+				{Field: "tags[1]", Message: "expected string, got integer"},
+				{Field: "tags[3]", Message: "expected string, got boolean"},
+			},
+		},
+		{
+			name: "errors in key order",
+			args: `{"name":"c","scale":"x","mode":"slow"}`,
+			want: []FieldError{
+				{Field: "mode", Message: "must be one of: fast, safe"},
+				{Field: "scale", Message: "expected number, got string"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateArguments(schema, decodeArgs(t, tt.args))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateArguments(%s) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateArgumentsAdditionalPropertiesAllowed(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+	}
+	if errs := validateArguments(schema, decodeArgs(t, `{"name":"c","extra":1}`)); len(errs) != 0 {
+		t.Errorf("validateArguments = %v, want no errors", errs)
+	}
+	if errs := validateArguments(schema, nil); len(errs) != 0 {
+		t.Errorf("validateArguments(nil) = %v, want no errors", errs)
+	}
+}
+
+func TestEnumEqual(t *testing.T) {
+	tests := []struct {
+		candidate interface{}
+		value     interface{}
+		want      bool
+	}{
+		{1, float64(1), true},
+		{int64(2), float64(2), true},
+		{float64(1.5), float64(1.5), true},
+		{1, "1", false},
+		{"1", float64(1), false},
+		{"true", true, false},
+		{true, true, true},
+		{"fast", "fast", true},
+		{nil, nil, true},
+		{nil, "null", false},
+	}
+	for _, tt := range tests {
+		if got := enumEqual(tt.candidate, tt.value); got != tt.want {
+			t.Errorf("enumEqual(%#v, %#v) = %v, want %v", tt.candidate, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestInvalidArgumentsError(t *testing.T) {
+	err := invalidArgumentsError("get_cluster", []FieldError{
+		{Field: "name", Message: "is required"},
+		{Field: "limit", Message: "expected integer, got string"},
+	})
+	if err.Code != -32602 {
+		t.Errorf("Code = %d, want -32602", err.Code)
+	}
+	want := "Invalid arguments for tool get_cluster: name is required; limit expected integer, got string"
+	if err.Message != want {
+		t.Errorf("Message = %q, want %q", err.Message, want)
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"time"
+)
+
+// Protocol revisions this server implements
+const (
+	ProtocolVersion20241105 = "2024-11-05"
+	ProtocolVersion20250326 = "2025-03-26"
+	ProtocolVersion20250618 = "2025-06-18"
+
+	// LatestProtocolVersion is offered to clients that request a revision
+	// this server does not implement
+	LatestProtocolVersion = ProtocolVersion20250618
+)
+
+// supportedProtocolVersions lists the implemented revisions, newest first
+var supportedProtocolVersions = []string{
+	ProtocolVersion20250618,
+	ProtocolVersion20250326,
+	ProtocolVersion20241105,
+}
+
+// IsSupportedProtocolVersion reports whether version is a revision this
+// server implements
+func IsSupportedProtocolVersion(version string) bool {
+	for _, supported := range supportedProtocolVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateProtocolVersion picks the revision to answer initialize with. A
+// supported request is echoed back. For a newer unknown revision the highest
+// older one we support is chosen; otherwise the latest is offered and the
+// client decides whether it can proceed.
+func negotiateProtocolVersion(requested string) string {
+	if IsSupportedProtocolVersion(requested) {
+		return requested
+	}
+	if _, ok := parseProtocolVersion(requested); !ok {
+		return LatestProtocolVersion
+	}
+	for _, supported := range supportedProtocolVersions {
+		if ProtocolVersionAtLeast(requested, supported) {
+			return supported
+		}
+	}
+	return LatestProtocolVersion
+}
+
+// parseProtocolVersion parses a revision, which is the date it was published
+func parseProtocolVersion(version string) (time.Time, bool) {
+	date, err := time.Parse(time.DateOnly, version)
+	return date, err == nil
+}
+
+// ProtocolVersionAtLeast reports whether version is the same as or newer
+// than min. A version that is not a revision date is never at least min.
+func ProtocolVersionAtLeast(version, min string) bool {
+	v, ok := parseProtocolVersion(version)
+	if !ok {
+		return false
+	}
+	m, ok := parseProtocolVersion(min)
+	return ok && !v.Before(m)
+}
+
+// protocolVersion returns the revision negotiated by the session in ctx.
+// Requests outside an initialized session are treated as the latest revision.
+func protocolVersion(ctx context.Context) string {
+	if sess, ok := SessionFromContext(ctx); ok {
+		if version := sess.ProtocolVersion(); version != "" {
+			return version
+		}
+	}
+	return LatestProtocolVersion
+}
+
+// toolForVersion strips the fields a client speaking version does not know
+func toolForVersion(tool Tool, version string) Tool {
+	if !ProtocolVersionAtLeast(version, ProtocolVersion20250618) {
+		tool.Title = ""
+		tool.OutputSchema = nil
+		tool.Meta = nil
+	}
+	if !ProtocolVersionAtLeast(version, ProtocolVersion20250326) {
+		tool.Annotations = nil
+	}
+	return tool
+}
@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+// initialize runs initialize outside a session with the given params
+func initialize(s *Server, params map[string]interface{}) *JSONRPCResponse {
+	return s.HandleRequest(context.Background(), &JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "initialize", Params: params})
+}
+
+func TestInitializeNegotiatesVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{"exact latest", ProtocolVersion20250618, ProtocolVersion20250618},
+		{"exact 2025-03-26", ProtocolVersion20250326, ProtocolVersion20250326},
+		{"exact oldest", ProtocolVersion20241105, ProtocolVersion20241105},
+		{"between revisions", "2025-05-01", ProtocolVersion20250326},
+		{"newer", "2026-01-15", ProtocolVersion20250618},
+		{"older than all", "2024-01-01", LatestProtocolVersion},
+		{"unknown", "draft", LatestProtocolVersion},
+		{"not zero-padded", "2025-6-18", LatestProtocolVersion},
+	}
+	s := NewServer("test", "1")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := initialize(s, map[string]interface{}{"protocolVersion": tt.requested})
+			if resp.Error != nil {
+				t.Fatalf("initialize failed: %s", resp.Error.Message)
+			}
+			if got := resp.Result.(InitializeResponse).ProtocolVersion; got != tt.want {
+				t.Errorf("protocolVersion = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitializeRequiresVersion(t *testing.T) {
+	s := NewServer("test", "1")
+	for _, params := range []map[string]interface{}{nil, {}, {"protocolVersion": ""}} {
+		if resp := initialize(s, params); resp.Error == nil || resp.Error.Code != -32602 {
+			t.Errorf("initialize with %v = %+v, want -32602", params, resp)
+		}
+	}
+}
+
+func TestInitializeCompletionsCapability(t *testing.T) {
+	s := NewServer("test", "1")
+	for version, want := range map[string]bool{
+		ProtocolVersion20241105: false,
+		ProtocolVersion20250326: true,
+		ProtocolVersion20250618: true,
+	} {
+		resp := initialize(s, map[string]interface{}{"protocolVersion": version})
+		if got := resp.Result.(InitializeResponse).Capabilities.Completions != nil; got != want {
+			t.Errorf("%s: completions capability = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestProtocolVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version, min string
+		want         bool
+	}{
+		{ProtocolVersion20250618, ProtocolVersion20250326, true},
+		{ProtocolVersion20250326, ProtocolVersion20250326, true},
+		{ProtocolVersion20241105, ProtocolVersion20250326, false},
+		{"", ProtocolVersion20241105, false},
+		{"draft", ProtocolVersion20241105, false},
+	}
+	for _, tt := range tests {
+		if got := ProtocolVersionAtLeast(tt.version, tt.min); got != tt.want {
+			t.Errorf("ProtocolVersionAtLeast(%q, %q) = %v, want %v", tt.version, tt.min, got, tt.want)
+		}
+	}
+}
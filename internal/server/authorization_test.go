@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/auth"
+)
+
+// bearerSubjects authenticates the bearer token as the subject of the same name
+type bearerSubjects struct{}
+
+func (bearerSubjects) Authenticate(r *http.Request) (*auth.Identity, error) {
+	subject := auth.BearerToken(r)
+	if subject == "" {
+		return nil, auth.ErrNoCredentials
+	}
+	return &auth.Identity{Subject: subject, Method: "test"}, nil
+}
+
+func TestPolicyCoversResourcesAndCompletions(t *testing.T) {
+	_, rancher := newRancherRecorder(t)
+	policy := &auth.Policy{Rules: []auth.PolicyRule{
+		{Subjects: []string{"test:operator"}, Allow: []string{"*"}},
+		{Subjects: []string{"test:auditor"}, Allow: []string{"list_*", "get_*"}, Deny: []string{"*_user*"}},
+	}}
+	_, ts := newTestHTTPServer(t, Options{
+		RancherURL:    rancher.URL,
+		RancherToken:  "server-token",
+		Authenticator: bearerSubjects{},
+		Policy:        policy,
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"read", `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"rancher://users/u-1"}}`},
+		{"read list", `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"rancher://users"}}`},
+		{"subscribe", `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"rancher://users"}}`},
+	}
+	auditor := initializeSession(t, ts, "Authorization", "Bearer auditor")
+	operator := initializeSession(t, ts, "Authorization", "Bearer operator")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := decodeResponse(t, mcpRequest(t, ts, http.MethodPost, auditor, tt.body, "Authorization", "Bearer auditor"))
+			rpcErr, _ := msg["error"].(map[string]interface{})
+			if code, _ := rpcErr["code"].(float64); code != -32001 {
+				t.Errorf("denied caller got %v, want error -32001", msg)
+			}
+			msg = decodeResponse(t, mcpRequest(t, ts, http.MethodPost, operator, tt.body, "Authorization", "Bearer operator"))
+			if msg["error"] != nil {
+				t.Errorf("allowed caller got error %v", msg["error"])
+			}
+		})
+	}
+
+	// Completions that would list users suggest nothing to the auditor
+	complete := `{"jsonrpc":"2.0","id":3,"method":"completion/complete","params":{"ref":{"type":"ref/prompt","name":"audit_user_access"},"argument":{"name":"user","value":""}}}`
+	msg := decodeResponse(t, mcpRequest(t, ts, http.MethodPost, auditor, complete, "Authorization", "Bearer auditor"))
+	result, _ := msg["result"].(map[string]interface{})
+	completion, _ := result["completion"].(map[string]interface{})
+	if values, _ := completion["values"].([]interface{}); completion == nil || len(values) != 0 {
+		t.Errorf("completion for the auditor = %v, want no values", msg)
+	}
+
+	// The templates list leaves out what the auditor may not read
+	msg = decodeResponse(t, mcpRequest(t, ts, http.MethodPost, auditor, `{"jsonrpc":"2.0","id":4,"method":"resources/templates/list"}`, "Authorization", "Bearer auditor"))
+	result, _ = msg["result"].(map[string]interface{})
+	templates, _ := result["resourceTemplates"].([]interface{})
+	if len(templates) == 0 {
+		t.Fatalf("templates list = %v, want the templates the auditor may read", msg)
+	}
+	for _, template := range templates {
+		if uri := template.(map[string]interface{})["uriTemplate"].(string); strings.Contains(uri, "users") {
+			t.Errorf("templates list for the auditor includes %s", uri)
+		}
+	}
+}
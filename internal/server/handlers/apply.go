@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// RegisterApplyTools registers the server-side apply tool
+func RegisterApplyTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
+	mcpServer.RegisterToolWithSchema("apply_resource", "Create or update a Rancher resource with server-side apply", map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"object": map[string]interface{}{
+				"type": "object",
+				"description": "Object with apiVersion, kind, metadata.name (and metadata.namespace for projects and role template bindings) and the fields to own. " +
+					"Fields set by others are kept; fields this tool applied before and left out now are removed",
+			},
+			"force":   forceProperty(),
+			"dry_run": dryRunProperty(),
+		},
+		"required": []string{"object"},
+	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return applyResource(ctx, args, rancherClient)
+	})
+}
+
+func applyResource(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
+	if rancherClient == nil {
+		return nil, fmt.Errorf("Rancher client not configured")
+	}
+	obj, ok := args["object"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("object parameter is required and must be an object")
+	}
+	opts := client.PatchOptions{DryRun: writeOptionsFromArgs(args).DryRun}
+	opts.Force, _ = args["force"].(bool)
+	return rancherClient.Apply(ctx, obj, opts)
+}
@@ -12,19 +12,19 @@ import (
 func RegisterAuditPolicyTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_audit_policies", "List all audit policies", map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listAuditPolicies(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_audit_policy", "Get details of a specific audit policy", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the audit policy",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getAuditPolicy(ctx, args, rancherClient)
@@ -32,19 +32,33 @@ func RegisterAuditPolicyTools(mcpServer *mcp.Server, rancherClient *client.Ranch
 }
 
 func listAuditPolicies(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
-	return rancherClient.ListAuditPolicies(ctx)
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return format.apply(listResult(rancherClient.ListAuditPolicies(ctx, opts)))
 }
 
 func getAuditPolicy(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetAuditPolicy(ctx, name)
+	return format.apply(rancherClient.GetAuditPolicy(ctx, name))
 }
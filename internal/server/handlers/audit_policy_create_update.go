@@ -17,13 +17,14 @@ func RegisterAuditPolicyCreateUpdateTools(mcpServer *mcp.Server, rancherClient *
 				"type":        "object",
 				"description": "Audit policy object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"policy"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createAuditPolicy(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_audit_policy", "Update/replace an audit policy", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_audit_policy", "Update/replace an audit policy, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterAuditPolicyCreateUpdateTools(mcpServer *mcp.Server, rancherClient *
 				"type":        "object",
 				"description": "Updated audit policy object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "policy"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateAuditPolicy(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterAuditPolicyCreateUpdateTools(mcpServer *mcp.Server, rancherClient *
 				"type":        "string",
 				"description": "The name of the audit policy",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterAuditPolicyCreateUpdateTools(mcpServer *mcp.Server, rancherClient *
 }
 
 func createAuditPolicy(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	policy, ok := args["policy"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("policy parameter is required and must be an object")
 	}
-	return rancherClient.CreateAuditPolicy(ctx, policy)
+	return rancherClient.CreateAuditPolicy(ctx, policy, opts)
 }
 
 func updateAuditPolicy(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "policy")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.AuditPolicyPath(name), opts, mergeChanges(changes))
+	}
 	policy, ok := args["policy"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("policy parameter is required and must be an object")
+		return nil, fmt.Errorf("policy or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateAuditPolicy(ctx, name, policy)
+	return rancherClient.UpdateAuditPolicy(ctx, name, policy, opts)
 }
 
 func patchAuditPolicy(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchAuditPolicy(ctx context.Context, args map[string]interface{}, rancherC
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchAuditPolicy(ctx, name, patch)
+	return rancherClient.PatchAuditPolicy(ctx, name, patch, opts)
 }
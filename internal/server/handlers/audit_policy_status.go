@@ -12,12 +12,12 @@ import (
 func RegisterAuditPolicyStatusTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("get_audit_policy_status", "Get status of a specific audit policy", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the audit policy",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getAuditPolicyStatus(ctx, args, rancherClient)
@@ -25,12 +25,17 @@ func RegisterAuditPolicyStatusTools(mcpServer *mcp.Server, rancherClient *client
 }
 
 func getAuditPolicyStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetAuditPolicyStatus(ctx, name)
+	return format.apply(rancherClient.GetAuditPolicyStatus(ctx, name))
 }
@@ -13,7 +13,7 @@ func RegisterBindingStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 	// ClusterRoleTemplateBinding status
 	mcpServer.RegisterToolWithSchema("get_cluster_role_template_binding_status", "Get status of a specific cluster role template binding", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the cluster role template binding",
@@ -22,7 +22,7 @@ func RegisterBindingStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 				"type":        "string",
 				"description": "Optional namespace of the cluster role template binding",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getClusterRoleTemplateBindingStatus(ctx, args, rancherClient)
@@ -31,7 +31,7 @@ func RegisterBindingStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 	// ProjectRoleTemplateBinding status
 	mcpServer.RegisterToolWithSchema("get_project_role_template_binding_status", "Get status of a specific project role template binding", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the project role template binding",
@@ -40,7 +40,7 @@ func RegisterBindingStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 				"type":        "string",
 				"description": "Optional namespace of the project role template binding",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getProjectRoleTemplateBindingStatus(ctx, args, rancherClient)
@@ -48,25 +48,35 @@ func RegisterBindingStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 }
 
 func getClusterRoleTemplateBindingStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.GetClusterRoleTemplateBindingStatus(ctx, name, namespace)
+	return format.apply(rancherClient.GetClusterRoleTemplateBindingStatus(ctx, name, namespace))
 }
 
 func getProjectRoleTemplateBindingStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.GetProjectRoleTemplateBindingStatus(ctx, name, namespace)
+	return format.apply(rancherClient.GetProjectRoleTemplateBindingStatus(ctx, name, namespace))
 }
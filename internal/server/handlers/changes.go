@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+)
+
+// changesProperty is the schema of the changes argument of update tools
+func changesProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "object",
+		"description": "Fields to change, merged into the latest version of the object as a JSON merge patch: maps are merged, other values replaced, and null removes a field. The update is retried if the object changes concurrently. Use instead of passing the whole object",
+	}
+}
+
+// changesFromArgs returns the changes argument of an update tool, or nil when
+// the caller passed the whole object under objectArg instead
+func changesFromArgs(args map[string]interface{}, objectArg string) (map[string]interface{}, error) {
+	raw, ok := args["changes"]
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := args[objectArg]; ok {
+		return nil, fmt.Errorf("pass either %s or changes, not both", objectArg)
+	}
+	changes, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("changes parameter must be an object")
+	}
+	return changes, nil
+}
+
+// mergeChanges returns a mutation for client.UpdateWithRetry that merges
+// changes into the object. A resourceVersion in changes is ignored, since
+// the update always applies to the version just read.
+func mergeChanges(changes map[string]interface{}) func(obj map[string]interface{}) error {
+	return func(obj map[string]interface{}) error {
+		resourceVersion := lookupField(obj, []string{"metadata", "resourceVersion"})
+		mergePatch(obj, changes)
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			if resourceVersion != nil {
+				metadata["resourceVersion"] = resourceVersion
+			} else {
+				delete(metadata, "resourceVersion")
+			}
+		}
+		return nil
+	}
+}
+
+// mergePatch applies patch to target following RFC 7386
+func mergePatch(target, patch map[string]interface{}) {
+	for key, value := range patch {
+		switch v := value.(type) {
+		case nil:
+			delete(target, key)
+		case map[string]interface{}:
+			existing, ok := target[key].(map[string]interface{})
+			if !ok {
+				existing = map[string]interface{}{}
+				target[key] = existing
+			}
+			mergePatch(existing, v)
+		default:
+			target[key] = v
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeChanges(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "admin",
+			"resourceVersion": "42",
+			"labels":          map[string]interface{}{"team": "a", "tier": "gold"},
+		},
+		"displayName": "Admin",
+		"rules":       []interface{}{"a", "b"},
+	}
+	changes := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"team": "b", "tier": nil},
+		},
+		"rules":       []interface{}{"c"},
+		"description": "Administrators",
+	}
+
+	if err := mergeChanges(changes)(obj); err != nil {
+		t.Fatalf("mergeChanges failed: %v", err)
+	}
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "admin",
+			"resourceVersion": "42",
+			"labels":          map[string]interface{}{"team": "b"},
+		},
+		"displayName": "Admin",
+		"rules":       []interface{}{"c"},
+		"description": "Administrators",
+	}
+	if !reflect.DeepEqual(obj, want) {
+		t.Errorf("merged object = %v, want %v", obj, want)
+	}
+}
+
+func TestChangesFromArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{"whole object", map[string]interface{}{"user": map[string]interface{}{}}, nil, false},
+		{"changes", map[string]interface{}{"changes": map[string]interface{}{"enabled": false}}, map[string]interface{}{"enabled": false}, false},
+		{"both", map[string]interface{}{"user": map[string]interface{}{}, "changes": map[string]interface{}{}}, nil, true},
+		{"not an object", map[string]interface{}{"changes": "enabled=false"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := changesFromArgs(tt.args, "user")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// clientFromContext returns the caller's own client when the transport
+// attached one to ctx, and the server-wide client otherwise
+func clientFromContext(ctx context.Context, fallback *client.RancherClient) *client.RancherClient {
+	if c, ok := client.FromContext(ctx); ok {
+		return c
+	}
+	return fallback
+}
+
+// dryRunProperty is the schema of the dry_run argument of tools that change
+// resources
+func dryRunProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Validate the request and return the object as Rancher would store it, without persisting anything",
+	}
+}
+
+// writeOptionsFromArgs reads the dry_run argument
+func writeOptionsFromArgs(args map[string]interface{}) client.WriteOptions {
+	dryRun, _ := args["dry_run"].(bool)
+	return client.WriteOptions{DryRun: dryRun}
+}
@@ -17,13 +17,14 @@ func RegisterClusterCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "object",
 				"description": "Cluster object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"cluster"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createCluster(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_cluster", "Update/replace a cluster", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_cluster", "Update/replace a cluster, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterClusterCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "object",
 				"description": "Updated cluster object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "cluster"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateCluster(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterClusterCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "string",
 				"description": "The name or ID of the cluster",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterClusterCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 }
 
 func createCluster(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	cluster, ok := args["cluster"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("cluster parameter is required and must be an object")
 	}
-	return rancherClient.CreateCluster(ctx, cluster)
+	return rancherClient.CreateCluster(ctx, cluster, opts)
 }
 
 func updateCluster(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "cluster")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.ClusterPath(name), opts, mergeChanges(changes))
+	}
 	cluster, ok := args["cluster"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("cluster parameter is required and must be an object")
+		return nil, fmt.Errorf("cluster or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateCluster(ctx, name, cluster)
+	return rancherClient.UpdateCluster(ctx, name, cluster, opts)
 }
 
 func patchCluster(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchCluster(ctx context.Context, args map[string]interface{}, rancherClien
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchCluster(ctx, name, patch)
+	return rancherClient.PatchCluster(ctx, name, patch, opts)
 }
@@ -17,6 +17,7 @@ func RegisterClusterDeleteTools(mcpServer *mcp.Server, rancherClient *client.Ran
 				"type":        "string",
 				"description": "The name or ID of the cluster to delete",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -25,12 +26,14 @@ func RegisterClusterDeleteTools(mcpServer *mcp.Server, rancherClient *client.Ran
 }
 
 func deleteCluster(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.DeleteCluster(ctx, name)
+	return rancherClient.DeleteCluster(ctx, name, opts)
 }
@@ -12,19 +12,19 @@ import (
 func RegisterClusterRoleTemplateBindingTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_cluster_role_template_bindings", "List all cluster role template bindings", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{
 			"namespace": map[string]interface{}{
 				"type":        "string",
 				"description": "Optional namespace to filter cluster role template bindings",
 			},
-		},
+		})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listClusterRoleTemplateBindings(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_cluster_role_template_binding", "Get details of a specific cluster role template binding", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the cluster role template binding",
@@ -33,7 +33,7 @@ func RegisterClusterRoleTemplateBindingTools(mcpServer *mcp.Server, rancherClien
 				"type":        "string",
 				"description": "Optional namespace of the cluster role template binding",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getClusterRoleTemplateBinding(ctx, args, rancherClient)
@@ -41,21 +41,35 @@ func RegisterClusterRoleTemplateBindingTools(mcpServer *mcp.Server, rancherClien
 }
 
 func listClusterRoleTemplateBindings(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.ListNamespacedClusterRoleTemplateBindings(ctx, namespace)
+	return format.apply(listResult(rancherClient.ListNamespacedClusterRoleTemplateBindings(ctx, namespace, opts)))
 }
 
 func getClusterRoleTemplateBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.GetClusterRoleTemplateBinding(ctx, name, namespace)
+	return format.apply(rancherClient.GetClusterRoleTemplateBinding(ctx, name, namespace))
 }
@@ -12,12 +12,12 @@ import (
 func RegisterClusterStatusTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("get_cluster_status", "Get status of a specific cluster", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name or ID of the cluster",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getClusterStatus(ctx, args, rancherClient)
@@ -25,12 +25,17 @@ func RegisterClusterStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 }
 
 func getClusterStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetClusterStatus(ctx, name)
+	return format.apply(rancherClient.GetClusterStatus(ctx, name))
 }
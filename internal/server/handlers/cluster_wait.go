@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const (
+	defaultClusterWaitTimeout  = 10 * time.Minute
+	maxClusterWaitTimeout      = time.Hour
+	defaultClusterPollInterval = 10 * time.Second
+	minClusterPollInterval     = 2 * time.Second
+)
+
+// RegisterClusterWaitTools registers tools that block until a cluster reaches a state
+func RegisterClusterWaitTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
+	mcpServer.RegisterToolWithSchema("wait_for_cluster_ready", "Wait until a cluster's Ready condition is True, reporting progress while it provisions", map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "The name or ID of the cluster",
+			},
+			"timeout_seconds": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum time to wait (default 600, maximum 3600)",
+			},
+			"poll_interval_seconds": map[string]interface{}{
+				"type":        "integer",
+				"description": "Time between status checks (default 10, minimum 2)",
+			},
+		},
+		"required": []string{"name"},
+	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return waitForClusterReady(ctx, args, rancherClient)
+	})
+}
+
+func waitForClusterReady(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
+	if rancherClient == nil {
+		return nil, fmt.Errorf("Rancher client not configured")
+	}
+	name, ok := args["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("name parameter is required")
+	}
+
+	timeout := durationArg(args, "timeout_seconds", defaultClusterWaitTimeout)
+	if timeout > maxClusterWaitTimeout {
+		timeout = maxClusterWaitTimeout
+	}
+	interval := durationArg(args, "poll_interval_seconds", defaultClusterPollInterval)
+	if interval < minClusterPollInterval {
+		interval = minClusterPollInterval
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := rancherClient.GetClusterStatus(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		ready, summary := clusterReadiness(status)
+		elapsed := time.Since(start)
+		mcp.ReportProgress(ctx, elapsed.Seconds(), timeout.Seconds(), fmt.Sprintf("Cluster %s: %s", name, summary))
+
+		if ready {
+			return map[string]interface{}{
+				"name":           name,
+				"ready":          true,
+				"elapsedSeconds": int(elapsed.Seconds()),
+				"status":         status,
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for cluster %s to become ready: %s", timeout, name, summary)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// clusterReadiness inspects a cluster status for the Ready condition and
+// summarizes the first condition that is holding it back
+func clusterReadiness(status interface{}) (bool, string) {
+	statusMap, _ := status.(map[string]interface{})
+	conditions, _ := statusMap["conditions"].([]interface{})
+
+	ready := false
+	var blocking string
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		conditionStatus, _ := condition["status"].(string)
+		if conditionType == "Ready" && conditionStatus == "True" {
+			ready = true
+		}
+		if conditionStatus != "True" && blocking == "" {
+			blocking = fmt.Sprintf("%s=%s", conditionType, conditionStatus)
+			if message, _ := condition["message"].(string); message != "" {
+				blocking = fmt.Sprintf("%s (%s)", blocking, message)
+			}
+		}
+	}
+
+	switch {
+	case ready:
+		return true, "Ready"
+	case blocking != "":
+		return false, "waiting on " + blocking
+	default:
+		return false, "waiting for status conditions"
+	}
+}
+
+// durationArg reads an integer number of seconds from args
+func durationArg(args map[string]interface{}, key string, fallback time.Duration) time.Duration {
+	seconds, ok := args[key].(float64)
+	if !ok || seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
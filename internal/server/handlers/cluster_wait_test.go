@@ -0,0 +1,27 @@
+package handlers
+
+import "testing"
+
+func TestClusterReadiness(t *testing.T) {
+	condition := func(conditionType, status, message string) interface{} {
+		return map[string]interface{}{"type": conditionType, "status": status, "message": message}
+	}
+	tests := []struct {
+		name       string
+		conditions []interface{}
+		ready      bool
+		summary    string
+	}{
+		{"ready", []interface{}{condition("Provisioned", "True", ""), condition("Ready", "True", "")}, true, "Ready"},
+		{"blocked", []interface{}{condition("Provisioned", "Unknown", "waiting for nodes"), condition("Ready", "False", "")}, false, "waiting on Provisioned=Unknown (waiting for nodes)"},
+		{"no conditions", nil, false, "waiting for status conditions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, summary := clusterReadiness(map[string]interface{}{"conditions": tt.conditions})
+			if ready != tt.ready || summary != tt.summary {
+				t.Errorf("clusterReadiness = %v, %q, want %v, %q", ready, summary, tt.ready, tt.summary)
+			}
+		})
+	}
+}
@@ -12,19 +12,19 @@ import (
 func RegisterClusterTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_clusters", "List all Rancher clusters", map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listClusters(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_cluster", "Get details of a specific cluster", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name or ID of the cluster",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getCluster(ctx, args, rancherClient)
@@ -32,19 +32,33 @@ func RegisterClusterTools(mcpServer *mcp.Server, rancherClient *client.RancherCl
 }
 
 func listClusters(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
-	return rancherClient.ListClusters(ctx)
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return format.apply(listResult(rancherClient.ListClusters(ctx, opts)))
 }
 
 func getCluster(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetCluster(ctx, name)
+	return format.apply(rancherClient.GetCluster(ctx, name))
 }
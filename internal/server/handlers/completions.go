@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// completionCacheTTL bounds how stale suggestions may be. Completion requests
+// arrive on every keystroke, so listing from Rancher each time is too slow.
+const completionCacheTTL = 30 * time.Second
+
+// completionObject is the part of a listed object that completers match on
+type completionObject struct {
+	name      string
+	namespace string
+}
+
+type completionCacheEntry struct {
+	objects []completionObject
+	fetched time.Time
+}
+
+// completionCacheKey separates cached lists by the client that fetched them,
+// so callers with their own credentials only see what they may list
+type completionCacheKey struct {
+	client *client.RancherClient
+	kind   string
+}
+
+// completionCache holds recent list results
+type completionCache struct {
+	mu      sync.Mutex
+	entries map[completionCacheKey]completionCacheEntry
+}
+
+func (c *completionCache) objects(ctx context.Context, rancherClient *client.RancherClient, kind string, list func(*client.RancherClient, context.Context, client.ListOptions) (interface{}, error)) ([]completionObject, error) {
+	key := completionCacheKey{client: rancherClient, kind: kind}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetched) < completionCacheTTL {
+		return entry.objects, nil
+	}
+
+	result, err := list(rancherClient, ctx, client.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	objects := listObjects(result)
+
+	c.mu.Lock()
+	// Drop expired lists, including those of per-caller clients that are gone
+	for k, e := range c.entries {
+		if time.Since(e.fetched) >= completionCacheTTL {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = completionCacheEntry{objects: objects, fetched: time.Now()}
+	c.mu.Unlock()
+	return objects, nil
+}
+
+// listObjects extracts names and namespaces from a Kubernetes list
+func listObjects(result interface{}) []completionObject {
+	list, _ := result.(map[string]interface{})
+	items, _ := list["items"].([]interface{})
+
+	objects := make([]completionObject, 0, len(items))
+	for _, item := range items {
+		obj, _ := item.(map[string]interface{})
+		metadata, _ := obj["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		if name != "" {
+			objects = append(objects, completionObject{name: name, namespace: namespace})
+		}
+	}
+	return objects
+}
+
+// prefixMatches returns the sorted, de-duplicated candidates starting with prefix
+func prefixMatches(candidates []string, prefix string) []string {
+	seen := make(map[string]bool, len(candidates))
+	matches := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if seen[candidate] || !strings.HasPrefix(candidate, prefix) {
+			continue
+		}
+		seen[candidate] = true
+		matches = append(matches, candidate)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// rancherCompleters builds completion handlers backed by cached Rancher lists
+type rancherCompleters struct {
+	mcpServer     *mcp.Server
+	rancherClient *client.RancherClient
+	cache         *completionCache
+}
+
+// listTools names the tool whose result each kind of completion reveals.
+// Callers that may not use it get no suggestions.
+var listTools = map[string]string{
+	"clusters":      "list_clusters",
+	"users":         "list_users",
+	"projects":      "list_projects",
+	"globalroles":   "list_global_roles",
+	"roletemplates": "list_role_templates",
+}
+
+// names completes object names of one kind, restricted to the namespace
+// already chosen in the namespaceArg argument when there is one
+func (rc *rancherCompleters) names(kind string, list func(*client.RancherClient, context.Context, client.ListOptions) (interface{}, error), namespaceArg string) mcp.CompletionHandler {
+	return func(ctx context.Context, value string, arguments map[string]string) ([]string, error) {
+		if !rc.mcpServer.ToolAllowed(ctx, listTools[kind]) {
+			return nil, nil
+		}
+		rancherClient := clientFromContext(ctx, rc.rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		objects, err := rc.cache.objects(ctx, rancherClient, kind, list)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := ""
+		if namespaceArg != "" {
+			namespace = arguments[namespaceArg]
+		}
+		candidates := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			if namespace == "" || obj.namespace == namespace {
+				candidates = append(candidates, obj.name)
+			}
+		}
+		return prefixMatches(candidates, value), nil
+	}
+}
+
+// qualifiedProjects completes project IDs in the cluster:project form used by
+// the prompts
+func (rc *rancherCompleters) qualifiedProjects() mcp.CompletionHandler {
+	return func(ctx context.Context, value string, arguments map[string]string) ([]string, error) {
+		if !rc.mcpServer.ToolAllowed(ctx, listTools["projects"]) {
+			return nil, nil
+		}
+		rancherClient := clientFromContext(ctx, rc.rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		objects, err := rc.cache.objects(ctx, rancherClient, "projects", (*client.RancherClient).ListProjects)
+		if err != nil {
+			return nil, err
+		}
+		candidates := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			candidates = append(candidates, obj.namespace+":"+obj.name)
+		}
+		return prefixMatches(candidates, value), nil
+	}
+}
+
+// RegisterCompletions wires argument completion for cluster, user, project,
+// global role and role template names into the tools, prompts and resource
+// templates that take them. It must run after those are registered.
+func RegisterCompletions(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
+	rc := &rancherCompleters{
+		mcpServer:     mcpServer,
+		rancherClient: rancherClient,
+		cache:         &completionCache{entries: make(map[completionCacheKey]completionCacheEntry)},
+	}
+
+	clusters := rc.names("clusters", (*client.RancherClient).ListClusters, "")
+	users := rc.names("users", (*client.RancherClient).ListUsers, "")
+	projects := rc.names("projects", (*client.RancherClient).ListProjects, "namespace")
+	globalRoles := rc.names("globalroles", (*client.RancherClient).ListGlobalRoles, "")
+	roleTemplates := rc.names("roletemplates", (*client.RancherClient).ListRoleTemplates, "")
+
+	// Tool arguments, keyed by the resource a tool acts on. Projects and
+	// cluster role template bindings live in their cluster's namespace.
+	toolCompleters := map[string]map[string]mcp.CompletionHandler{
+		"cluster":                        {"name": clusters},
+		"cluster_ready":                  {"name": clusters},
+		"user":                           {"name": users},
+		"project":                        {"name": projects, "namespace": clusters},
+		"projects":                       {"namespace": clusters},
+		"global_role":                    {"name": globalRoles},
+		"role_template":                  {"name": roleTemplates},
+		"cluster_role_template_binding":  {"namespace": clusters},
+		"cluster_role_template_bindings": {"namespace": clusters},
+	}
+	for _, name := range mcpServer.ToolNames() {
+		_, resource, _ := strings.Cut(name, "_")
+		resource = strings.TrimPrefix(resource, "for_")
+		resource = strings.TrimSuffix(resource, "_status")
+		for argument, handler := range toolCompleters[resource] {
+			mcpServer.RegisterCompletion(mcp.CompletionReference{Type: "ref/tool", Name: name}, argument, handler)
+		}
+	}
+
+	promptCompleters := map[string]map[string]mcp.CompletionHandler{
+		"onboard_user_to_project":       {"user": users, "project": rc.qualifiedProjects(), "role": roleTemplates},
+		"investigate_unhealthy_cluster": {"cluster": clusters},
+		"audit_user_access":             {"user": users},
+	}
+	for prompt, arguments := range promptCompleters {
+		for argument, handler := range arguments {
+			mcpServer.RegisterCompletion(mcp.CompletionReference{Type: "ref/prompt", Name: prompt}, argument, handler)
+		}
+	}
+
+	templateCompleters := map[string]map[string]mcp.CompletionHandler{
+		"rancher://clusters/{name}":                                {"name": clusters},
+		"rancher://users/{name}":                                   {"name": users},
+		"rancher://projects/{namespace}":                           {"namespace": clusters},
+		"rancher://projects/{namespace}/{name}":                    {"namespace": clusters, "name": projects},
+		"rancher://clusterroletemplatebindings/{namespace}/{name}": {"namespace": clusters},
+	}
+	for uri, arguments := range templateCompleters {
+		for argument, handler := range arguments {
+			mcpServer.RegisterCompletion(mcp.CompletionReference{Type: "ref/resource", URI: uri}, argument, handler)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const projectListJSON = `{"items": [
+  {"metadata": {"name": "p-system", "namespace": "c-1"}},
+  {"metadata": {"name": "p-default", "namespace": "c-1"}},
+  {"metadata": {"name": "p-default", "namespace": "c-2"}},
+  {"metadata": {"name": "p-apps", "namespace": "c-2"}}
+]}`
+
+func newCompleters(rancherClient *client.RancherClient) *rancherCompleters {
+	return &rancherCompleters{
+		mcpServer:     mcp.NewServer("test", "1"),
+		rancherClient: rancherClient,
+		cache:         &completionCache{entries: make(map[completionCacheKey]completionCacheEntry)},
+	}
+}
+
+func complete(t *testing.T, ctx context.Context, handler mcp.CompletionHandler, value string, arguments map[string]string) string {
+	t.Helper()
+	values, err := handler(ctx, value, arguments)
+	if err != nil {
+		t.Fatalf("completion of %q failed: %v", value, err)
+	}
+	return strings.Join(values, ",")
+}
+
+func TestPrefixMatches(t *testing.T) {
+	candidates := []string{"c-2", "local", "c-1", "c-2", "C-3"}
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "C-3,c-1,c-2,local"},
+		{"c-", "c-1,c-2"},
+		{"c-2", "c-2"},
+		{"x", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(prefixMatches(candidates, tt.prefix), ","); got != tt.want {
+			t.Errorf("prefixMatches(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteNames(t *testing.T) {
+	_, rancherClient := newFakeRancher(t, map[string]string{client.ProjectsPath(""): projectListJSON})
+	rc := newCompleters(rancherClient)
+	projects := rc.names("projects", (*client.RancherClient).ListProjects, "namespace")
+	ctx := context.Background()
+
+	tests := []struct {
+		value     string
+		namespace string
+		want      string
+	}{
+		{"p-", "", "p-apps,p-default,p-system"},
+		{"p-", "c-1", "p-default,p-system"},
+		{"p-a", "c-1", ""},
+		{"p-a", "c-2", "p-apps"},
+		{"p-", "c-9", ""},
+	}
+	for _, tt := range tests {
+		got := complete(t, ctx, projects, tt.value, map[string]string{"namespace": tt.namespace})
+		if got != tt.want {
+			t.Errorf("complete(%q, namespace %q) = %q, want %q", tt.value, tt.namespace, got, tt.want)
+		}
+	}
+}
+
+func TestCompleteQualifiedProjects(t *testing.T) {
+	_, rancherClient := newFakeRancher(t, map[string]string{client.ProjectsPath(""): projectListJSON})
+	projects := newCompleters(rancherClient).qualifiedProjects()
+	ctx := context.Background()
+
+	if got := complete(t, ctx, projects, "", nil); got != "c-1:p-default,c-1:p-system,c-2:p-apps,c-2:p-default" {
+		t.Errorf("all projects = %q", got)
+	}
+	if got := complete(t, ctx, projects, "c-2:p-d", nil); got != "c-2:p-default" {
+		t.Errorf("c-2:p-d = %q, want c-2:p-default", got)
+	}
+}
+
+func TestCompletionCacheExpiry(t *testing.T) {
+	cache := &completionCache{entries: make(map[completionCacheKey]completionCacheEntry)}
+	rancherClient := client.NewRancherClient("https://rancher.example.com", "token", false)
+	calls := 0
+	list := func(*client.RancherClient, context.Context, client.ListOptions) (interface{}, error) {
+		calls++
+		return map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "c-1"}},
+		}}, nil
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.objects(ctx, rancherClient, "clusters", list); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("listed %d times within the TTL, want 1", calls)
+	}
+
+	key := completionCacheKey{client: rancherClient, kind: "clusters"}
+	entry := cache.entries[key]
+	entry.fetched = time.Now().Add(-completionCacheTTL)
+	cache.entries[key] = entry
+	if _, err := cache.objects(ctx, rancherClient, "clusters", list); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("listed %d times after the TTL, want 2", calls)
+	}
+
+	// Expired lists of other clients are dropped on the next fetch
+	other := client.NewRancherClient("https://rancher.example.com", "other", false)
+	cache.entries[completionCacheKey{client: other, kind: "clusters"}] = completionCacheEntry{fetched: time.Now().Add(-completionCacheTTL)}
+	if _, err := cache.objects(ctx, rancherClient, "users", list); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.entries[completionCacheKey{client: other, kind: "clusters"}]; ok {
+		t.Error("expired list of another client was kept")
+	}
+}
+
+func TestCompletionCachePerClient(t *testing.T) {
+	_, alice := newFakeRancher(t, map[string]string{client.ClustersPath(): `{"items": [{"metadata": {"name": "c-alice"}}]}`})
+	_, bob := newFakeRancher(t, map[string]string{client.ClustersPath(): `{"items": [{"metadata": {"name": "c-bob"}}]}`})
+	rc := newCompleters(nil)
+	clusters := rc.names("clusters", (*client.RancherClient).ListClusters, "")
+
+	// Each passthrough caller completes from the list its own client fetched,
+	// even while the other's list is cached
+	for i := 0; i < 2; i++ {
+		if got := complete(t, client.NewContext(context.Background(), alice), clusters, "c-", nil); got != "c-alice" {
+			t.Errorf("alice sees %q, want c-alice", got)
+		}
+		if got := complete(t, client.NewContext(context.Background(), bob), clusters, "c-", nil); got != "c-bob" {
+			t.Errorf("bob sees %q, want c-bob", got)
+		}
+	}
+}
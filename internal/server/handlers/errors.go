@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// FormatError describes a handler error for MCP clients. Rancher API errors
+// get a short text that says whether retrying can help, instead of the raw
+// response.
+func FormatError(err error) string {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	message := apiErr.Message
+	if message == "" {
+		message = apiErr.Body
+	}
+	if message == "" {
+		message = http.StatusText(apiErr.StatusCode)
+	}
+
+	switch {
+	case client.IsUnauthorized(err):
+		return "Unauthorized: Rancher rejected the API token, which may be invalid or expired. Retrying will not help"
+	case client.IsForbidden(err):
+		return fmt.Sprintf("Forbidden: %s. The Rancher token lacks this permission; retrying will not help", message)
+	case client.IsNotFound(err):
+		return fmt.Sprintf("Not found: %s", message)
+	case client.IsAlreadyExists(err):
+		return fmt.Sprintf("Already exists: %s", message)
+	case client.IsConflict(err):
+		return fmt.Sprintf("Conflict: %s. The object changed since it was read; get it again and reapply the change", message)
+	case client.IsInvalid(err):
+		return fmt.Sprintf("Invalid: %s", message)
+	case apiErr.RetryAfterSeconds() > 0:
+		return fmt.Sprintf("Rancher is busy: %s. Retry after %d seconds", message, apiErr.RetryAfterSeconds())
+	case client.IsTooManyRequests(err):
+		return fmt.Sprintf("Rate limited: %s. Retry later", message)
+	case apiErr.Reason != "":
+		return fmt.Sprintf("Rancher API error %d %s: %s", apiErr.StatusCode, apiErr.Reason, message)
+	default:
+		return fmt.Sprintf("Rancher API error %d: %s", apiErr.StatusCode, message)
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+func TestFormatError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "not found",
+			err:  &client.APIError{StatusCode: 404, Reason: "NotFound", Message: `clusters.management.cattle.io "c-x" not found`},
+			want: `Not found: clusters.management.cattle.io "c-x" not found`,
+		},
+		{
+			name: "forbidden and wrapped",
+			err:  fmt.Errorf("token verification failed: %w", &client.APIError{StatusCode: 403, Reason: "Forbidden", Message: "users is forbidden"}),
+			want: "Forbidden: users is forbidden. The Rancher token lacks this permission; retrying will not help",
+		},
+		{
+			name: "unauthorized without a Status",
+			err:  &client.APIError{StatusCode: 401, Body: `{"type":"error","status":"401"}`},
+			want: "Unauthorized: Rancher rejected the API token, which may be invalid or expired. Retrying will not help",
+		},
+		{
+			name: "already exists is not a conflict",
+			err:  &client.APIError{StatusCode: 409, Reason: "AlreadyExists", Message: `projects "p-1" already exists`},
+			want: `Already exists: projects "p-1" already exists`,
+		},
+		{
+			name: "conflict",
+			err:  &client.APIError{StatusCode: 409, Reason: "Conflict", Message: "the object has been modified"},
+			want: "Conflict: the object has been modified. The object changed since it was read; get it again and reapply the change",
+		},
+		{
+			name: "409 without a reason is a conflict",
+			err:  &client.APIError{StatusCode: 409, Body: "the object has been modified"},
+			want: "Conflict: the object has been modified. The object changed since it was read; get it again and reapply the change",
+		},
+		{
+			name: "retry after",
+			err: &client.APIError{StatusCode: 500, Reason: "ServerTimeout", Message: "try again",
+				Details: &client.StatusDetails{RetryAfterSeconds: 5}},
+			want: "Rancher is busy: try again. Retry after 5 seconds",
+		},
+		{
+			name: "other status",
+			err:  &client.APIError{StatusCode: 502},
+			want: "Rancher API error 502: Bad Gateway",
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("name parameter is required"),
+			want: "name parameter is required",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FormatError(tc.err); got != tc.want {
+				t.Errorf("FormatError() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -17,13 +17,14 @@ func RegisterGlobalRoleBindingCreateUpdateTools(mcpServer *mcp.Server, rancherCl
 				"type":        "object",
 				"description": "Global role binding object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"binding"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createGlobalRoleBinding(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_global_role_binding", "Update/replace a global role binding", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_global_role_binding", "Update/replace a global role binding, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterGlobalRoleBindingCreateUpdateTools(mcpServer *mcp.Server, rancherCl
 				"type":        "object",
 				"description": "Updated global role binding object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "binding"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateGlobalRoleBinding(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterGlobalRoleBindingCreateUpdateTools(mcpServer *mcp.Server, rancherCl
 				"type":        "string",
 				"description": "The name of the global role binding",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterGlobalRoleBindingCreateUpdateTools(mcpServer *mcp.Server, rancherCl
 }
 
 func createGlobalRoleBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	binding, ok := args["binding"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("binding parameter is required and must be an object")
 	}
-	return rancherClient.CreateGlobalRoleBinding(ctx, binding)
+	return rancherClient.CreateGlobalRoleBinding(ctx, binding, opts)
 }
 
 func updateGlobalRoleBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "binding")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.GlobalRoleBindingPath(name), opts, mergeChanges(changes))
+	}
 	binding, ok := args["binding"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("binding parameter is required and must be an object")
+		return nil, fmt.Errorf("binding or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateGlobalRoleBinding(ctx, name, binding)
+	return rancherClient.UpdateGlobalRoleBinding(ctx, name, binding, opts)
 }
 
 func patchGlobalRoleBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchGlobalRoleBinding(ctx context.Context, args map[string]interface{}, ra
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchGlobalRoleBinding(ctx, name, patch)
+	return rancherClient.PatchGlobalRoleBinding(ctx, name, patch, opts)
 }
@@ -12,19 +12,19 @@ import (
 func RegisterGlobalRoleBindingTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_global_role_bindings", "List all global role bindings", map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listGlobalRoleBindings(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_global_role_binding", "Get details of a specific global role binding", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the global role binding",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getGlobalRoleBinding(ctx, args, rancherClient)
@@ -32,19 +32,33 @@ func RegisterGlobalRoleBindingTools(mcpServer *mcp.Server, rancherClient *client
 }
 
 func listGlobalRoleBindings(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
-	return rancherClient.ListGlobalRoleBindings(ctx)
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return format.apply(listResult(rancherClient.ListGlobalRoleBindings(ctx, opts)))
 }
 
 func getGlobalRoleBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetGlobalRoleBinding(ctx, name)
+	return format.apply(rancherClient.GetGlobalRoleBinding(ctx, name))
 }
@@ -17,13 +17,14 @@ func RegisterGlobalRoleCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "object",
 				"description": "Global role object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"role"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createGlobalRole(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_global_role", "Update/replace a global role", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_global_role", "Update/replace a global role, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterGlobalRoleCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "object",
 				"description": "Updated global role object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "role"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateGlobalRole(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterGlobalRoleCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "string",
 				"description": "The name of the global role",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterGlobalRoleCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 }
 
 func createGlobalRole(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	role, ok := args["role"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("role parameter is required and must be an object")
 	}
-	return rancherClient.CreateGlobalRole(ctx, role)
+	return rancherClient.CreateGlobalRole(ctx, role, opts)
 }
 
 func updateGlobalRole(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "role")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.GlobalRolePath(name), opts, mergeChanges(changes))
+	}
 	role, ok := args["role"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("role parameter is required and must be an object")
+		return nil, fmt.Errorf("role or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateGlobalRole(ctx, name, role)
+	return rancherClient.UpdateGlobalRole(ctx, name, role, opts)
 }
 
 func patchGlobalRole(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchGlobalRole(ctx context.Context, args map[string]interface{}, rancherCl
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchGlobalRole(ctx, name, patch)
+	return rancherClient.PatchGlobalRole(ctx, name, patch, opts)
 }
@@ -17,6 +17,7 @@ func RegisterGlobalRoleDeleteTools(mcpServer *mcp.Server, rancherClient *client.
 				"type":        "string",
 				"description": "The name of the global role to delete",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -25,12 +26,14 @@ func RegisterGlobalRoleDeleteTools(mcpServer *mcp.Server, rancherClient *client.
 }
 
 func deleteGlobalRole(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.DeleteGlobalRole(ctx, name)
+	return rancherClient.DeleteGlobalRole(ctx, name, opts)
 }
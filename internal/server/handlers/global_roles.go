@@ -12,19 +12,19 @@ import (
 func RegisterGlobalRoleTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_global_roles", "List all global roles", map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listGlobalRoles(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_global_role", "Get details of a specific global role", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the global role",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getGlobalRole(ctx, args, rancherClient)
@@ -32,19 +32,33 @@ func RegisterGlobalRoleTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 }
 
 func listGlobalRoles(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
-	return rancherClient.ListGlobalRoles(ctx)
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return format.apply(listResult(rancherClient.ListGlobalRoles(ctx, opts)))
 }
 
 func getGlobalRole(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetGlobalRole(ctx, name)
+	return format.apply(rancherClient.GetGlobalRole(ctx, name))
 }
@@ -17,13 +17,14 @@ func RegisterKubeconfigCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "object",
 				"description": "Kubeconfig object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"kubeconfig"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createKubeconfig(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_kubeconfig", "Update/replace a kubeconfig", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_kubeconfig", "Update/replace a kubeconfig, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterKubeconfigCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "object",
 				"description": "Updated kubeconfig object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "kubeconfig"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateKubeconfig(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterKubeconfigCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 				"type":        "string",
 				"description": "The name of the kubeconfig",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterKubeconfigCreateUpdateTools(mcpServer *mcp.Server, rancherClient *c
 }
 
 func createKubeconfig(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	kubeconfig, ok := args["kubeconfig"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("kubeconfig parameter is required and must be an object")
 	}
-	return rancherClient.CreateKubeconfig(ctx, kubeconfig)
+	return rancherClient.CreateKubeconfig(ctx, kubeconfig, opts)
 }
 
 func updateKubeconfig(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "kubeconfig")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.KubeconfigPath(name), opts, mergeChanges(changes))
+	}
 	kubeconfig, ok := args["kubeconfig"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("kubeconfig parameter is required and must be an object")
+		return nil, fmt.Errorf("kubeconfig or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateKubeconfig(ctx, name, kubeconfig)
+	return rancherClient.UpdateKubeconfig(ctx, name, kubeconfig, opts)
 }
 
 func patchKubeconfig(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchKubeconfig(ctx context.Context, args map[string]interface{}, rancherCl
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchKubeconfig(ctx, name, patch)
+	return rancherClient.PatchKubeconfig(ctx, name, patch, opts)
 }
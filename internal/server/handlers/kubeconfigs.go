@@ -12,19 +12,19 @@ import (
 func RegisterKubeconfigTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_kubeconfigs", "List all kubeconfigs", map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{},
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listKubeconfigs(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_kubeconfig", "Get details of a specific kubeconfig", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the kubeconfig",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getKubeconfig(ctx, args, rancherClient)
@@ -32,19 +32,33 @@ func RegisterKubeconfigTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 }
 
 func listKubeconfigs(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
-	return rancherClient.ListKubeconfigs(ctx)
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return format.apply(listResult(rancherClient.ListKubeconfigs(ctx, opts)))
 }
 
 func getKubeconfig(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetKubeconfig(ctx, name)
+	return format.apply(rancherClient.GetKubeconfig(ctx, name))
 }
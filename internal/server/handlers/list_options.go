@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// withListOptionProperties adds the paging and selector arguments shared by
+// all list tools to properties
+func withListOptionProperties(properties map[string]interface{}) map[string]interface{} {
+	properties["limit"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "Maximum number of items to return. When more remain, the result carries a continue token",
+	}
+	properties["continue"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Continue token from a previous result, to fetch the next page",
+	}
+	properties["label_selector"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Kubernetes label selector, for example 'app=web,tier!=db'",
+	}
+	properties["field_selector"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Kubernetes field selector, for example 'metadata.name=local'",
+	}
+	return properties
+}
+
+// listOptionsFromArgs reads the paging and selector arguments
+func listOptionsFromArgs(args map[string]interface{}) (client.ListOptions, error) {
+	var opts client.ListOptions
+	if limit, ok := args["limit"].(float64); ok {
+		if limit < 1 || limit != math.Trunc(limit) {
+			return opts, fmt.Errorf("limit must be a positive integer")
+		}
+		opts.Limit = int64(limit)
+	}
+	opts.Continue, _ = args["continue"].(string)
+	opts.LabelSelector, _ = args["label_selector"].(string)
+	opts.FieldSelector, _ = args["field_selector"].(string)
+	return opts, nil
+}
+
+// listResult copies the continue token of a partial list to the top level of
+// the result, where it sorts ahead of the items in the serialized output
+func listResult(result interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	list, ok := result.(map[string]interface{})
+	if !ok {
+		return result, nil
+	}
+	metadata, _ := list["metadata"].(map[string]interface{})
+	if token, _ := metadata["continue"].(string); token != "" {
+		list["continue"] = token
+	}
+	return list, nil
+}
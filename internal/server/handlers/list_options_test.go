@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+func TestListOptionsFromArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    client.ListOptions
+		wantErr bool
+	}{
+		{"none", map[string]interface{}{}, client.ListOptions{}, false},
+		{"page", map[string]interface{}{"limit": float64(50), "continue": "token"}, client.ListOptions{Limit: 50, Continue: "token"}, false},
+		{
+			"selectors",
+			map[string]interface{}{"label_selector": "app=web", "field_selector": "metadata.name=local"},
+			client.ListOptions{LabelSelector: "app=web", FieldSelector: "metadata.name=local"},
+			false,
+		},
+		{"zero limit", map[string]interface{}{"limit": float64(0)}, client.ListOptions{}, true},
+		{"negative limit", map[string]interface{}{"limit": float64(-5)}, client.ListOptions{}, true},
+		{"fractional limit", map[string]interface{}{"limit": 2.5}, client.ListOptions{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := listOptionsFromArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && opts != tt.want {
+				t.Errorf("options = %+v, want %+v", opts, tt.want)
+			}
+		})
+	}
+}
+
+func TestListResultContinue(t *testing.T) {
+	partial := map[string]interface{}{"metadata": map[string]interface{}{"continue": "next"}, "items": []interface{}{}}
+	result, err := listResult(partial, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token := result.(map[string]interface{})["continue"]; token != "next" {
+		t.Errorf("continue = %v, want next", token)
+	}
+
+	complete := map[string]interface{}{"metadata": map[string]interface{}{"continue": ""}, "items": []interface{}{}}
+	result, _ = listResult(complete, nil)
+	if _, ok := result.(map[string]interface{})["continue"]; ok {
+		t.Error("a complete list has a top-level continue")
+	}
+}
+
+func TestListClustersPaging(t *testing.T) {
+	fake, rancherClient := newFakeRancher(t, map[string]string{
+		client.ClustersPath(): `{"metadata": {"continue": "page-3"}, "items": [{"metadata": {"name": "c-1"}}]}`,
+	})
+	s := mcp.NewServer("test", "1")
+	RegisterClusterTools(s, rancherClient)
+	ApplyToolMetadata(s)
+	ctx := newSession(t, s, mcp.ProtocolVersion20250618)
+
+	// Numbers are float64, as decoded from a JSON request
+	result := callTool(t, ctx, s, "list_clusters", map[string]interface{}{"limit": float64(1), "continue": "page-2"})
+	if token := result.StructuredContent.(map[string]interface{})["continue"]; token != "page-3" {
+		t.Errorf("continue = %v, want page-3", token)
+	}
+	u := fake.waitForRequest(t)
+	if query := u.Query(); query.Get("limit") != "1" || query.Get("continue") != "page-2" {
+		t.Errorf("query = %s, want limit 1 and continue page-2", u.RawQuery)
+	}
+
+	resp := request(ctx, s, "tools/call", map[string]interface{}{"name": "list_clusters", "arguments": map[string]interface{}{"limit": float64(0)}})
+	if resp.Error == nil && !resp.Result.(mcp.CallToolResponse).IsError {
+		t.Error("list_clusters with limit 0 succeeded")
+	}
+}
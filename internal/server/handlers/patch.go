@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// patchProperty is the schema of the patch argument of patch tools
+func patchProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        []string{"object", "array"},
+		"description": "Patch to apply. For merge, an object whose maps are merged and whose arrays and other values replace the current ones. For json, an array of RFC 6902 operations such as {\"op\": \"remove\", \"path\": \"/rules/2\"}. For apply, a partial object including apiVersion and kind",
+	}
+}
+
+// patchTypeProperty is the schema of the patch_type argument of patch tools
+func patchTypeProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "string",
+		"enum": []string{string(client.MergePatch), string(client.JSONPatch), string(client.ApplyPatch)},
+		"description": "merge (default) replaces whole arrays; use json to add, replace or remove single array items such as role rules; apply does a server-side apply as the " +
+			client.FieldManager + " field manager",
+	}
+}
+
+// forceProperty is the schema of the force argument of server-side apply
+func forceProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "With server-side apply, take ownership of fields set by other field managers instead of failing with a conflict",
+	}
+}
+
+// patchFromArgs reads the patch, patch_type, force and dry_run arguments. It
+// returns the options to send the patch with and the patch itself.
+func patchFromArgs(args map[string]interface{}) (client.PatchOptions, interface{}, error) {
+	opts := client.PatchOptions{Type: client.MergePatch, DryRun: writeOptionsFromArgs(args).DryRun}
+	if patchType, _ := args["patch_type"].(string); patchType != "" {
+		opts.Type = client.PatchType(patchType)
+	}
+	opts.Force, _ = args["force"].(bool)
+	if opts.Force && opts.Type != client.ApplyPatch {
+		return opts, nil, fmt.Errorf("force is only valid with patch_type apply")
+	}
+
+	switch patch := args["patch"].(type) {
+	case []interface{}:
+		if opts.Type != client.JSONPatch {
+			return opts, nil, fmt.Errorf("patch must be an object for patch_type %s", opts.Type)
+		}
+		return opts, patch, nil
+	case map[string]interface{}:
+		if opts.Type == client.JSONPatch {
+			return opts, nil, fmt.Errorf("patch must be an array of operations for patch_type json")
+		}
+		return opts, patch, nil
+	default:
+		return opts, nil, fmt.Errorf("patch parameter is required and must be an object or array")
+	}
+}
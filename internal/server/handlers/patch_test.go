@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+func TestPatchFromArgs(t *testing.T) {
+	object := map[string]interface{}{"description": "x"}
+	ops := []interface{}{map[string]interface{}{"op": "remove", "path": "/rules/0"}}
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    client.PatchOptions
+		wantErr bool
+	}{
+		{"merge by default", map[string]interface{}{"patch": object}, client.PatchOptions{Type: client.MergePatch}, false},
+		{"json", map[string]interface{}{"patch": ops, "patch_type": "json"}, client.PatchOptions{Type: client.JSONPatch}, false},
+		{"forced apply", map[string]interface{}{"patch": object, "patch_type": "apply", "force": true}, client.PatchOptions{Type: client.ApplyPatch, Force: true}, false},
+		{"dry run", map[string]interface{}{"patch": object, "dry_run": true}, client.PatchOptions{Type: client.MergePatch, DryRun: true}, false},
+		{"json with an object", map[string]interface{}{"patch": object, "patch_type": "json"}, client.PatchOptions{}, true},
+		{"merge with an array", map[string]interface{}{"patch": ops}, client.PatchOptions{}, true},
+		{"force without apply", map[string]interface{}{"patch": object, "force": true}, client.PatchOptions{}, true},
+		{"no patch", map[string]interface{}{}, client.PatchOptions{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, patch, err := patchFromArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if patch == nil {
+				t.Error("patchFromArgs returned no patch")
+			}
+			if opts != tt.want {
+				t.Errorf("options = %+v, want %+v", opts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// resultFormat post-processes the Kubernetes object or list returned by a
+// get or list tool. Results are always trimmed of managedFields and the
+// last-applied annotation; fields and output are chosen by the caller.
+type resultFormat struct {
+	fields [][]string
+	output string
+}
+
+// withOutputProperties adds the fields and output arguments shared by the get
+// and list tools to properties
+func withOutputProperties(properties map[string]interface{}) map[string]interface{} {
+	properties["fields"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Comma-separated field paths to keep, for example 'metadata.name,status.conditions'. Applies to each item of a list",
+	}
+	properties["output"] = map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{outputJSON, outputYAML, outputTable},
+		"description": "Result format: json (default), yaml, or table with one row per object",
+	}
+	return properties
+}
+
+// resultFormatFromArgs reads the fields and output arguments
+func resultFormatFromArgs(args map[string]interface{}) (*resultFormat, error) {
+	format := &resultFormat{output: outputJSON}
+	if output, _ := args["output"].(string); output != "" {
+		format.output = output
+	}
+	switch format.output {
+	case outputJSON, outputYAML, outputTable:
+	default:
+		return nil, fmt.Errorf("output must be one of: json, yaml, table")
+	}
+
+	fields, _ := args["fields"].(string)
+	for _, field := range strings.Split(fields, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		path, err := parseFieldPath(field)
+		if err != nil {
+			return nil, err
+		}
+		format.fields = append(format.fields, path)
+	}
+	return format, nil
+}
+
+// parseFieldPath splits a JSONPath-style field such as "metadata.name",
+// "{.status.conditions[*].type}" or "metadata.labels.app\.kubernetes\.io/name"
+// into its keys. A key that reaches an array applies to each element.
+func parseFieldPath(field string) ([]string, error) {
+	path := strings.TrimSpace(field)
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+	path = strings.ReplaceAll(path, "[*]", "")
+
+	var keys []string
+	var key strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	keys = append(keys, key.String())
+
+	for _, k := range keys {
+		if k == "" || strings.ContainsAny(k, "[]") {
+			return nil, fmt.Errorf("invalid field %q: use dot-separated keys such as metadata.name", strings.TrimSpace(field))
+		}
+	}
+	return keys, nil
+}
+
+// apply post-processes the result of a Rancher client call
+func (f *resultFormat) apply(result interface{}, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := result.(map[string]interface{})
+	if !ok {
+		return result, nil
+	}
+
+	items, isList := obj["items"].([]interface{})
+	if isList {
+		for i, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				items[i] = f.project(trimObject(itemObj))
+			}
+		}
+	} else {
+		obj = f.project(trimObject(obj))
+	}
+
+	switch f.output {
+	case outputYAML:
+		return &mcp.TextResult{Text: marshalYAML(obj), Structured: obj}, nil
+	case outputTable:
+		return &mcp.TextResult{Text: f.table(obj, isList), Structured: obj}, nil
+	default:
+		return obj, nil
+	}
+}
+
+// trimObject drops metadata that is large and of no use to a reader
+func trimObject(obj map[string]interface{}) map[string]interface{} {
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		return obj
+	}
+	delete(metadata, "managedFields")
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		delete(annotations, lastAppliedAnnotation)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+	return obj
+}
+
+// project keeps only the requested fields of obj, preserving their nesting
+func (f *resultFormat) project(obj map[string]interface{}) map[string]interface{} {
+	if len(f.fields) == 0 {
+		return obj
+	}
+	var out interface{} = map[string]interface{}{}
+	for _, path := range f.fields {
+		if picked, ok := pickField(obj, path); ok {
+			out = mergeFields(out, picked)
+		}
+	}
+	projected, _ := out.(map[string]interface{})
+	return projected
+}
+
+// pickField returns value pruned to path. Arrays keep their length, with nil
+// for elements that lack the field, so that picks of sibling fields line up
+// when merged.
+func pickField(value interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return nil, false
+		}
+		picked, ok := pickField(child, path[1:])
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{path[0]: picked}, true
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i], _ = pickField(item, path)
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func mergeFields(a, b interface{}) interface{} {
+	switch av := a.(type) {
+	case nil:
+		return b
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return b
+		}
+		for key, value := range bv {
+			av[key] = mergeFields(av[key], value)
+		}
+		return av
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return b
+		}
+		for i := range av {
+			av[i] = mergeFields(av[i], bv[i])
+		}
+		return av
+	default:
+		return b
+	}
+}
+
+// table renders one row per object. With fields, each field is a column;
+// otherwise the columns are the name, namespace, display name and age.
+func (f *resultFormat) table(obj map[string]interface{}, isList bool) string {
+	var rows []map[string]interface{}
+	if isList {
+		items, _ := obj["items"].([]interface{})
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				rows = append(rows, itemObj)
+			}
+		}
+	} else {
+		rows = append(rows, obj)
+	}
+
+	var headers []string
+	var cells []func(map[string]interface{}) string
+	if len(f.fields) > 0 {
+		for _, path := range f.fields {
+			path := path
+			headers = append(headers, strings.ToUpper(strings.Join(path, ".")))
+			cells = append(cells, func(row map[string]interface{}) string {
+				return tableCell(lookupField(row, path))
+			})
+		}
+	} else {
+		headers, cells = defaultColumns(rows)
+	}
+
+	var buf bytes.Buffer
+	if len(rows) == 0 {
+		buf.WriteString("No resources found\n")
+	} else {
+		w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			values := make([]string, len(cells))
+			for i, cell := range cells {
+				values[i] = cell(row)
+			}
+			fmt.Fprintln(w, strings.Join(values, "\t"))
+		}
+		w.Flush()
+	}
+
+	if token, _ := obj["continue"].(string); token != "" {
+		fmt.Fprintf(&buf, "\nMore items are available. Pass continue: %s to fetch the next page.\n", token)
+	}
+	return buf.String()
+}
+
+// defaultColumns picks kubectl-like columns, leaving out namespace and
+// display name when no row has them
+func defaultColumns(rows []map[string]interface{}) ([]string, []func(map[string]interface{}) string) {
+	displayName := func(row map[string]interface{}) interface{} {
+		if name := lookupField(row, []string{"spec", "displayName"}); name != nil {
+			return name
+		}
+		return lookupField(row, []string{"displayName"})
+	}
+	hasColumn := func(value func(map[string]interface{}) interface{}) bool {
+		for _, row := range rows {
+			if value(row) != nil {
+				return true
+			}
+		}
+		return false
+	}
+	namespace := func(row map[string]interface{}) interface{} {
+		return lookupField(row, []string{"metadata", "namespace"})
+	}
+
+	headers := []string{"NAME"}
+	cells := []func(map[string]interface{}) string{
+		func(row map[string]interface{}) string {
+			return tableCell(lookupField(row, []string{"metadata", "name"}))
+		},
+	}
+	if hasColumn(namespace) {
+		headers = append(headers, "NAMESPACE")
+		cells = append(cells, func(row map[string]interface{}) string { return tableCell(namespace(row)) })
+	}
+	if hasColumn(displayName) {
+		headers = append(headers, "DISPLAY NAME")
+		cells = append(cells, func(row map[string]interface{}) string { return tableCell(displayName(row)) })
+	}
+	headers = append(headers, "AGE")
+	cells = append(cells, func(row map[string]interface{}) string {
+		created, _ := lookupField(row, []string{"metadata", "creationTimestamp"}).(string)
+		return age(created)
+	})
+	return headers, cells
+}
+
+// lookupField returns the value at path, collecting it from each element of
+// any array along the way
+func lookupField(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return lookupField(v[path[0]], path[1:])
+	case []interface{}:
+		var out []interface{}
+		for _, item := range v {
+			if found := lookupField(item, path); found != nil {
+				out = append(out, found)
+			}
+		}
+		if out == nil {
+			return nil
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func tableCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return strings.Join(strings.Fields(v), " ")
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = tableCell(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = key + "=" + tableCell(v[key])
+		}
+		return strings.Join(parts, ",")
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// age formats the time since an RFC 3339 timestamp the way kubectl does
+func age(timestamp string) string {
+	created, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "<unknown>"
+	}
+	d := time.Since(created)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const clusterListJSON = `{
+  "apiVersion": "management.cattle.io/v3",
+  "kind": "ClusterList",
+  "metadata": {"continue": "next-page"},
+  "continue": "next-page",
+  "items": [
+    {
+      "metadata": {
+        "name": "local",
+        "creationTimestamp": "CREATED",
+        "managedFields": [{"manager": "rancher"}],
+        "annotations": {"kubectl.kubernetes.io/last-applied-configuration": "{...}"},
+        "labels": {"app.kubernetes.io/name": "rancher"}
+      },
+      "spec": {"displayName": "local cluster"},
+      "status": {"conditions": [
+        {"type": "Ready", "status": "True", "message": "ok"},
+        {"type": "Provisioned", "status": "True"}
+      ]}
+    },
+    {
+      "metadata": {
+        "name": "c-m-abc",
+        "creationTimestamp": "CREATED",
+        "annotations": {"note": "keep", "kubectl.kubernetes.io/last-applied-configuration": "{...}"}
+      },
+      "spec": {"displayName": "prod"},
+      "status": {"conditions": [{"type": "Ready", "status": "False"}]}
+    }
+  ]
+}`
+
+func clusterList(t *testing.T) map[string]interface{} {
+	t.Helper()
+	created := time.Now().Add(-3 * time.Hour).UTC().Format(time.RFC3339)
+	var list map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(clusterListJSON, "CREATED", created)), &list); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	return list
+}
+
+func formatFor(t *testing.T, args map[string]interface{}) *resultFormat {
+	t.Helper()
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		t.Fatalf("resultFormatFromArgs failed: %v", err)
+	}
+	return format
+}
+
+func toJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	return string(data)
+}
+
+func TestResultTrimming(t *testing.T) {
+	result, err := formatFor(t, nil).apply(clusterList(t), nil)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	out := toJSON(t, result)
+	for _, removed := range []string{"managedFields", "last-applied-configuration"} {
+		if strings.Contains(out, removed) {
+			t.Errorf("%s was not removed: %s", removed, out)
+		}
+	}
+	for _, kept := range []string{`"note":"keep"`, `"continue":"next-page"`, `"displayName":"prod"`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%s is missing: %s", kept, out)
+		}
+	}
+	// Annotations left empty by trimming are dropped altogether
+	first := result.(map[string]interface{})["items"].([]interface{})[0].(map[string]interface{})
+	if _, ok := first["metadata"].(map[string]interface{})["annotations"]; ok {
+		t.Error("empty annotations were kept")
+	}
+}
+
+func TestResultFieldProjection(t *testing.T) {
+	format := formatFor(t, map[string]interface{}{
+		"fields": `metadata.name, {.status.conditions[*].type}, $.status.conditions.status, metadata.labels.app\.kubernetes\.io/name, spec.missing`,
+	})
+	result, err := format.apply(clusterList(t), nil)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	items := toJSON(t, result.(map[string]interface{})["items"])
+	want := `[{"metadata":{"labels":{"app.kubernetes.io/name":"rancher"},"name":"local"},"status":{"conditions":[{"status":"True","type":"Ready"},{"status":"True","type":"Provisioned"}]}},` +
+		`{"metadata":{"name":"c-m-abc"},"status":{"conditions":[{"status":"False","type":"Ready"}]}}]`
+	if items != want {
+		t.Errorf("projected items:\n got %s\nwant %s", items, want)
+	}
+
+	// A single object is projected as a whole
+	cluster := clusterList(t)["items"].([]interface{})[0]
+	result, err = formatFor(t, map[string]interface{}{"fields": "metadata.name"}).apply(cluster, nil)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if got := toJSON(t, result); got != `{"metadata":{"name":"local"}}` {
+		t.Errorf("projected object = %s", got)
+	}
+}
+
+func TestResultFormatArguments(t *testing.T) {
+	for _, args := range []map[string]interface{}{
+		{"output": "xml"},
+		{"fields": "metadata..name"},
+		{"fields": "status.conditions[0]"},
+	} {
+		if _, err := resultFormatFromArgs(args); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestResultTable(t *testing.T) {
+	result, err := formatFor(t, map[string]interface{}{"output": "table"}).apply(clusterList(t), nil)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	text, ok := result.(*mcp.TextResult)
+	if !ok {
+		t.Fatalf("expected a TextResult, got %T", result)
+	}
+	want := "NAME      DISPLAY NAME    AGE\n" +
+		"local     local cluster   3h\n" +
+		"c-m-abc   prod            3h\n" +
+		"\nMore items are available. Pass continue: next-page to fetch the next page.\n"
+	if text.Text != want {
+		t.Errorf("table:\n%s\nwant:\n%s", text.Text, want)
+	}
+	if _, ok := text.Structured.(map[string]interface{}); !ok {
+		t.Error("table result lost its structured content")
+	}
+
+	result, _ = formatFor(t, map[string]interface{}{
+		"output": "table",
+		"fields": "metadata.name,status.conditions.type",
+	}).apply(clusterList(t), nil)
+	want = "METADATA.NAME   STATUS.CONDITIONS.TYPE\n" +
+		"local           Ready,Provisioned\n" +
+		"c-m-abc         Ready\n"
+	if got := result.(*mcp.TextResult).Text; !strings.HasPrefix(got, want) {
+		t.Errorf("table with fields:\n%s\nwant prefix:\n%s", got, want)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	value := map[string]interface{}{
+		"kind":  "Cluster",
+		"empty": map[string]interface{}{},
+		"metadata": map[string]interface{}{
+			"name":   "c-m-abc",
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "rancher", "tier": "1"},
+		},
+		"spec": map[string]interface{}{
+			"enabled":  true,
+			"replicas": float64(3),
+			"ratio":    0.5,
+			"none":     nil,
+			"note":     "key: value",
+			"switch":   "on",
+			"cert":     "-----BEGIN-----\nabc\n-----END-----\n",
+			"tags":     []interface{}{"a", "b"},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Updated", "status": "False"},
+			},
+		},
+	}
+	want := `empty: {}
+kind: Cluster
+metadata:
+  labels:
+    app.kubernetes.io/name: rancher
+    tier: "1"
+  name: c-m-abc
+spec:
+  cert: |
+    -----BEGIN-----
+    abc
+    -----END-----
+  conditions:
+  - status: "True"
+    type: Ready
+  - status: "False"
+    type: Updated
+  enabled: true
+  none: null
+  note: "key: value"
+  ratio: 0.5
+  replicas: 3
+  switch: "on"
+  tags:
+  - a
+  - b
+`
+	if got := marshalYAML(value); got != want {
+		t.Errorf("marshalYAML:\n%s\nwant:\n%s", got, want)
+	}
+}
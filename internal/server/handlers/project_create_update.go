@@ -21,13 +21,14 @@ func RegisterProjectCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "string",
 				"description": "Optional namespace for the project",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"project"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createProject(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_project", "Update/replace a project", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_project", "Update/replace a project, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -42,8 +43,10 @@ func RegisterProjectCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "string",
 				"description": "Optional namespace of the project",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "project"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateProject(ctx, args, rancherClient)
 	})
@@ -55,14 +58,14 @@ func RegisterProjectCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 				"type":        "string",
 				"description": "The name or ID of the project",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
 			"namespace": map[string]interface{}{
 				"type":        "string",
 				"description": "Optional namespace of the project",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -71,34 +74,46 @@ func RegisterProjectCreateUpdateTools(mcpServer *mcp.Server, rancherClient *clie
 }
 
 func createProject(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	project, ok := args["project"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("project parameter is required and must be an object")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.CreateProject(ctx, project, namespace)
+	return rancherClient.CreateProject(ctx, project, namespace, opts)
 }
 
 func updateProject(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	namespace, _ := args["namespace"].(string)
+	changes, err := changesFromArgs(args, "project")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.ProjectPath(name, namespace), opts, mergeChanges(changes))
+	}
 	project, ok := args["project"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("project parameter is required and must be an object")
+		return nil, fmt.Errorf("project or changes parameter is required and must be an object")
 	}
-	namespace, _ := args["namespace"].(string)
-	return rancherClient.UpdateProject(ctx, name, project, namespace)
+	return rancherClient.UpdateProject(ctx, name, project, namespace, opts)
 }
 
 func patchProject(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -106,10 +121,10 @@ func patchProject(ctx context.Context, args map[string]interface{}, rancherClien
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.PatchProject(ctx, name, patch, namespace)
+	return rancherClient.PatchProject(ctx, name, patch, namespace, opts)
 }
@@ -21,6 +21,7 @@ func RegisterProjectDeleteTools(mcpServer *mcp.Server, rancherClient *client.Ran
 				"type":        "string",
 				"description": "Optional namespace of the project",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -29,13 +30,15 @@ func RegisterProjectDeleteTools(mcpServer *mcp.Server, rancherClient *client.Ran
 }
 
 func deleteProject(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.DeleteProject(ctx, name, namespace)
+	return rancherClient.DeleteProject(ctx, name, namespace, opts)
 }
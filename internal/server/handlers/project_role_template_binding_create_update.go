@@ -21,13 +21,14 @@ func RegisterProjectRoleTemplateBindingCreateUpdateTools(mcpServer *mcp.Server,
 				"type":        "string",
 				"description": "Optional namespace for the binding",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"binding"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createProjectRoleTemplateBinding(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_project_role_template_binding", "Update/replace a project role template binding", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_project_role_template_binding", "Update/replace a project role template binding, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -42,8 +43,10 @@ func RegisterProjectRoleTemplateBindingCreateUpdateTools(mcpServer *mcp.Server,
 				"type":        "string",
 				"description": "Optional namespace of the binding",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "binding"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateProjectRoleTemplateBinding(ctx, args, rancherClient)
 	})
@@ -55,14 +58,14 @@ func RegisterProjectRoleTemplateBindingCreateUpdateTools(mcpServer *mcp.Server,
 				"type":        "string",
 				"description": "The name of the project role template binding",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
 			"namespace": map[string]interface{}{
 				"type":        "string",
 				"description": "Optional namespace of the binding",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -71,34 +74,46 @@ func RegisterProjectRoleTemplateBindingCreateUpdateTools(mcpServer *mcp.Server,
 }
 
 func createProjectRoleTemplateBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	binding, ok := args["binding"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("binding parameter is required and must be an object")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.CreateProjectRoleTemplateBinding(ctx, binding, namespace)
+	return rancherClient.CreateProjectRoleTemplateBinding(ctx, binding, namespace, opts)
 }
 
 func updateProjectRoleTemplateBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	namespace, _ := args["namespace"].(string)
+	changes, err := changesFromArgs(args, "binding")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.ProjectRoleTemplateBindingPath(name, namespace), opts, mergeChanges(changes))
+	}
 	binding, ok := args["binding"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("binding parameter is required and must be an object")
+		return nil, fmt.Errorf("binding or changes parameter is required and must be an object")
 	}
-	namespace, _ := args["namespace"].(string)
-	return rancherClient.UpdateProjectRoleTemplateBinding(ctx, name, binding, namespace)
+	return rancherClient.UpdateProjectRoleTemplateBinding(ctx, name, binding, namespace, opts)
 }
 
 func patchProjectRoleTemplateBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -106,10 +121,10 @@ func patchProjectRoleTemplateBinding(ctx context.Context, args map[string]interf
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.PatchProjectRoleTemplateBinding(ctx, name, patch, namespace)
+	return rancherClient.PatchProjectRoleTemplateBinding(ctx, name, patch, namespace, opts)
 }
@@ -21,6 +21,7 @@ func RegisterProjectRoleTemplateBindingDeleteTools(mcpServer *mcp.Server, ranche
 				"type":        "string",
 				"description": "Optional namespace of the binding",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -29,13 +30,15 @@ func RegisterProjectRoleTemplateBindingDeleteTools(mcpServer *mcp.Server, ranche
 }
 
 func deleteProjectRoleTemplateBinding(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.DeleteProjectRoleTemplateBinding(ctx, name, namespace)
+	return rancherClient.DeleteProjectRoleTemplateBinding(ctx, name, namespace, opts)
 }
@@ -12,7 +12,7 @@ import (
 func RegisterProjectStatusTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("get_project_status", "Get status of a specific project", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name or ID of the project",
@@ -21,7 +21,7 @@ func RegisterProjectStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 				"type":        "string",
 				"description": "Optional namespace of the project",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getProjectStatus(ctx, args, rancherClient)
@@ -29,13 +29,18 @@ func RegisterProjectStatusTools(mcpServer *mcp.Server, rancherClient *client.Ran
 }
 
 func getProjectStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.GetProjectStatus(ctx, name, namespace)
+	return format.apply(rancherClient.GetProjectStatus(ctx, name, namespace))
 }
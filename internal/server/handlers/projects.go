@@ -12,19 +12,19 @@ import (
 func RegisterProjectTools(mcpServer *mcp.Server, rancherClient *client.RancherClient) {
 	mcpServer.RegisterToolWithSchema("list_projects", "List all Rancher projects", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(withListOptionProperties(map[string]interface{}{
 			"namespace": map[string]interface{}{
 				"type":        "string",
 				"description": "Optional namespace to filter projects",
 			},
-		},
+		})),
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return listProjects(ctx, args, rancherClient)
 	})
 
 	mcpServer.RegisterToolWithSchema("get_project", "Get details of a specific project", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name or ID of the project",
@@ -33,7 +33,7 @@ func RegisterProjectTools(mcpServer *mcp.Server, rancherClient *client.RancherCl
 				"type":        "string",
 				"description": "Optional namespace of the project",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getProject(ctx, args, rancherClient)
@@ -41,21 +41,35 @@ func RegisterProjectTools(mcpServer *mcp.Server, rancherClient *client.RancherCl
 }
 
 func listProjects(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts, err := listOptionsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.ListNamespacedProjects(ctx, namespace)
+	return format.apply(listResult(rancherClient.ListNamespacedProjects(ctx, namespace, opts)))
 }
 
 func getProject(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
 	namespace, _ := args["namespace"].(string)
-	return rancherClient.GetProject(ctx, name, namespace)
+	return format.apply(rancherClient.GetProject(ctx, name, namespace))
 }
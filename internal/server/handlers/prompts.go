@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// RegisterPrompts registers the built-in operational workflow prompts
+func RegisterPrompts(mcpServer *mcp.Server) {
+	mcpServer.RegisterPrompt(mcp.Prompt{
+		Name:        "onboard_user_to_project",
+		Description: "Grant a user a role in a project and verify the binding took effect",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "user",
+				Description: "The Rancher user ID (for example u-abc123)",
+				Required:    true,
+			},
+			{
+				Name:        "project",
+				Description: "The project ID in cluster:project form (for example c-m-abc123:p-xyz789)",
+				Required:    true,
+			},
+			{
+				Name:        "role",
+				Description: "The project role template ID (for example project-member or read-only)",
+				Required:    true,
+			},
+		},
+	}, func(ctx context.Context, args map[string]string) ([]mcp.PromptMessage, error) {
+		return onboardUserToProjectPrompt(args)
+	})
+
+	mcpServer.RegisterPrompt(mcp.Prompt{
+		Name:        "investigate_unhealthy_cluster",
+		Description: "Diagnose why a cluster is not Active using its status conditions",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "cluster",
+				Description: "The cluster ID (for example c-m-abc123)",
+				Required:    true,
+			},
+		},
+	}, func(ctx context.Context, args map[string]string) ([]mcp.PromptMessage, error) {
+		return investigateUnhealthyClusterPrompt(args)
+	})
+
+	mcpServer.RegisterPrompt(mcp.Prompt{
+		Name:        "audit_user_access",
+		Description: "Summarize every global, cluster and project permission a user holds",
+		Arguments: []mcp.PromptArgument{
+			{
+				Name:        "user",
+				Description: "The Rancher user ID (for example u-abc123)",
+				Required:    true,
+			},
+		},
+	}, func(ctx context.Context, args map[string]string) ([]mcp.PromptMessage, error) {
+		return auditUserAccessPrompt(args)
+	})
+}
+
+func onboardUserToProjectPrompt(args map[string]string) ([]mcp.PromptMessage, error) {
+	user := args["user"]
+	project := args["project"]
+	role := args["role"]
+
+	clusterID, projectID, ok := strings.Cut(project, ":")
+	if !ok || clusterID == "" || projectID == "" {
+		return nil, fmt.Errorf("project must be in cluster:project form, for example c-m-abc123:p-xyz789")
+	}
+
+	text := fmt.Sprintf(`Onboard user %[1]s to project %[2]s with role %[3]s. Work through these steps in order and stop to report if any step fails.
+
+1. Call get_user with name "%[1]s" to confirm the user exists and is enabled.
+2. Call get_project with name "%[5]s" and namespace "%[4]s" to confirm the project exists.
+3. Call get_role_template with name "%[3]s" and confirm its "context" is "project". Cluster-context role templates cannot be bound to a project.
+4. Call list_project_role_template_bindings with namespace "%[5]s" and check whether %[1]s already has %[3]s. If so, report that nothing needs to change.
+5. Call create_project_role_template_binding with namespace "%[5]s" and this binding:
+   {
+     "apiVersion": "management.cattle.io/v3",
+     "kind": "ProjectRoleTemplateBinding",
+     "metadata": {"generateName": "prtb-", "namespace": "%[5]s"},
+     "projectName": "%[2]s",
+     "roleTemplateName": "%[3]s",
+     "userName": "%[1]s"
+   }
+6. Call get_project_role_template_binding_status with the name returned in step 5 and namespace "%[5]s" to verify the binding was reconciled.
+
+Finish with a short summary of what was granted.`, user, project, role, clusterID, projectID)
+
+	return []mcp.PromptMessage{
+		{
+			Role: "user",
+			Content: mcp.Content{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+func investigateUnhealthyClusterPrompt(args map[string]string) ([]mcp.PromptMessage, error) {
+	cluster := args["cluster"]
+
+	text := fmt.Sprintf(`Investigate why cluster %[1]s is unhealthy. This is a read-only investigation: do not call any create, update, patch or delete tools.
+
+1. Call get_cluster_status with name "%[1]s". Look at each entry in "conditions" and list every condition whose status is not "True", with its reason, message and lastUpdateTime.
+2. Call get_cluster with name "%[1]s" and note the Kubernetes version, the provider (driver) and the agent image.
+3. If the "Connected" or "Ready" condition is False, the cluster agent has likely lost contact with Rancher. Say so and suggest checking the cattle-cluster-agent pods in the downstream cluster.
+4. If the "Provisioned" or "Updated" condition is False, the failure is in provisioning. Quote the condition message, because it usually names the failing node or machine.
+5. Call list_projects with namespace "%[1]s" to confirm whether the System and Default projects still exist.
+
+Report the most likely root cause first, followed by the evidence and the next steps an operator should take.`, cluster)
+
+	return []mcp.PromptMessage{
+		{
+			Role: "user",
+			Content: mcp.Content{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
+
+func auditUserAccessPrompt(args map[string]string) ([]mcp.PromptMessage, error) {
+	user := args["user"]
+
+	text := fmt.Sprintf(`Audit the access held by user %[1]s. This is a read-only audit: do not call any create, update, patch or delete tools.
+
+1. Call get_user with name "%[1]s" and note the username, display name and whether the user is enabled.
+2. Call list_global_role_bindings and keep the bindings whose "userName" is "%[1]s". For each, call get_global_role on its "globalRoleName" and note whether the role grants admin or restricted-admin.
+3. Call list_cluster_role_template_bindings and keep the bindings whose "userName" is "%[1]s". Group them by "clusterName".
+4. Call list_project_role_template_bindings and keep the bindings whose "userName" is "%[1]s". Group them by "projectName".
+5. Call list_tokens and count the tokens owned by this user, noting any without an expiry.
+
+Present the result as a table of scope (global, cluster or project), target and role. Call out anything that grants admin-level access.`, user)
+
+	return []mcp.PromptMessage{
+		{
+			Role: "user",
+			Content: mcp.Content{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}, nil
+}
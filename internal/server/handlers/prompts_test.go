@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+func newPromptServer() *mcp.Server {
+	s := mcp.NewServer("test", "1")
+	RegisterPrompts(s)
+	return s
+}
+
+func TestPromptsList(t *testing.T) {
+	resp := request(context.Background(), newPromptServer(), "prompts/list", nil)
+	if resp.Error != nil {
+		t.Fatalf("prompts/list failed: %s", resp.Error.Message)
+	}
+	required := make(map[string][]string)
+	for _, prompt := range resp.Result.(mcp.PromptListResponse).Prompts {
+		for _, arg := range prompt.Arguments {
+			if arg.Required {
+				required[prompt.Name] = append(required[prompt.Name], arg.Name)
+			}
+		}
+	}
+	want := map[string]string{
+		"onboard_user_to_project":       "user,project,role",
+		"investigate_unhealthy_cluster": "cluster",
+		"audit_user_access":             "user",
+	}
+	if len(required) != len(want) {
+		t.Errorf("prompts = %v, want %d prompts", required, len(want))
+	}
+	for name, args := range want {
+		if got := strings.Join(required[name], ","); got != args {
+			t.Errorf("%s requires %q, want %q", name, got, args)
+		}
+	}
+}
+
+func TestPromptsGet(t *testing.T) {
+	s := newPromptServer()
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		contains []string
+	}{
+		{
+			"onboard_user_to_project",
+			map[string]interface{}{"user": "u-abc", "project": "c-m-1:p-xyz", "role": "project-member"},
+			[]string{`Onboard user u-abc to project c-m-1:p-xyz with role project-member`, `namespace "c-m-1"`, `"projectName": "c-m-1:p-xyz"`, `name "p-xyz"`},
+		},
+		{
+			"investigate_unhealthy_cluster",
+			map[string]interface{}{"cluster": "c-m-1"},
+			[]string{`cluster c-m-1 is unhealthy`, `get_cluster_status with name "c-m-1"`},
+		},
+		{
+			"audit_user_access",
+			map[string]interface{}{"user": "u-abc"},
+			[]string{`user u-abc`, `"userName" is "u-abc"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(context.Background(), s, "prompts/get", map[string]interface{}{"name": tt.name, "arguments": tt.args})
+			if resp.Error != nil {
+				t.Fatalf("prompts/get failed: %s", resp.Error.Message)
+			}
+			messages := resp.Result.(mcp.GetPromptResponse).Messages
+			if len(messages) != 1 || messages[0].Role != "user" {
+				t.Fatalf("messages = %+v, want one user message", messages)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(messages[0].Content.Text, want) {
+					t.Errorf("message does not contain %q:\n%s", want, messages[0].Content.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestPromptsGetErrors(t *testing.T) {
+	s := newPromptServer()
+	// code is 0 where any error code will do
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		code   int
+		text   string
+	}{
+		{"no name", map[string]interface{}{}, -32602, "name is required"},
+		{"unknown prompt", map[string]interface{}{"name": "delete_everything"}, -32602, "Prompt not found: delete_everything"},
+		{
+			"missing arguments",
+			map[string]interface{}{"name": "onboard_user_to_project", "arguments": map[string]interface{}{"user": "u-abc"}},
+			-32602, "missing required arguments: project, role",
+		},
+		{
+			"blank argument",
+			map[string]interface{}{"name": "audit_user_access", "arguments": map[string]interface{}{"user": "  "}},
+			-32602, "missing required arguments: user",
+		},
+		{
+			"project not in cluster:project form",
+			map[string]interface{}{"name": "onboard_user_to_project", "arguments": map[string]interface{}{"user": "u-abc", "project": "p-xyz", "role": "project-member"}},
+			0, "cluster:project form",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := request(context.Background(), s, "prompts/get", tt.params)
+			if resp.Error == nil {
+				t.Fatalf("prompts/get = %+v, want an error", resp.Result)
+			}
+			if (tt.code != 0 && resp.Error.Code != tt.code) || !strings.Contains(resp.Error.Message, tt.text) {
+				t.Errorf("error = %d %q, want %d containing %q", resp.Error.Code, resp.Error.Message, tt.code, tt.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// RegisterResources registers Rancher objects as MCP resources
+func RegisterResources(mcpServer *mcp.Server, rancherClient *client.RancherClient) error {
+	// Clusters
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://clusters",
+		Name:        "clusters",
+		Description: "All Rancher clusters",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListClusters(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://clusters/{name}",
+		Name:        "cluster",
+		Description: "A specific Rancher cluster by name or ID",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetCluster(ctx, params["name"])
+	}); err != nil {
+		return err
+	}
+
+	// Projects
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://projects",
+		Name:        "projects",
+		Description: "All Rancher projects across namespaces",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListProjectsAllNamespaces(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://projects/{namespace}",
+		Name:        "cluster-projects",
+		Description: "Rancher projects in a namespace (the namespace is the cluster ID)",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListNamespacedProjects(ctx, params["namespace"], client.ListOptions{})
+	}); err != nil {
+		return err
+	}
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://projects/{namespace}/{name}",
+		Name:        "project",
+		Description: "A specific Rancher project by namespace and name",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetProject(ctx, params["name"], params["namespace"])
+	}); err != nil {
+		return err
+	}
+
+	// Users
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://users",
+		Name:        "users",
+		Description: "All Rancher users",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListUsers(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://users/{name}",
+		Name:        "user",
+		Description: "A specific Rancher user by name or ID",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetUser(ctx, params["name"])
+	}); err != nil {
+		return err
+	}
+
+	// GlobalRoleBindings
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://globalrolebindings",
+		Name:        "globalrolebindings",
+		Description: "All Rancher global role bindings",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListGlobalRoleBindings(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://globalrolebindings/{name}",
+		Name:        "globalrolebinding",
+		Description: "A specific Rancher global role binding",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetGlobalRoleBinding(ctx, params["name"])
+	}); err != nil {
+		return err
+	}
+
+	// ClusterRoleTemplateBindings
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://clusterroletemplatebindings",
+		Name:        "clusterroletemplatebindings",
+		Description: "All Rancher cluster role template bindings",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListClusterRoleTemplateBindings(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://clusterroletemplatebindings/{namespace}/{name}",
+		Name:        "clusterroletemplatebinding",
+		Description: "A specific Rancher cluster role template binding by namespace and name",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetClusterRoleTemplateBinding(ctx, params["name"], params["namespace"])
+	}); err != nil {
+		return err
+	}
+
+	// ProjectRoleTemplateBindings
+	mcpServer.RegisterResource(mcp.Resource{
+		URI:         "rancher://projectroletemplatebindings",
+		Name:        "projectroletemplatebindings",
+		Description: "All Rancher project role template bindings",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.ListProjectRoleTemplateBindings(ctx, client.ListOptions{})
+	})
+	if err := mcpServer.RegisterResourceTemplate(mcp.ResourceTemplate{
+		URITemplate: "rancher://projectroletemplatebindings/{namespace}/{name}",
+		Name:        "projectroletemplatebinding",
+		Description: "A specific Rancher project role template binding by namespace and name",
+	}, func(ctx context.Context, uri string, params map[string]string) (interface{}, error) {
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return nil, fmt.Errorf("Rancher client not configured")
+		}
+		return rancherClient.GetProjectRoleTemplateBinding(ctx, params["name"], params["namespace"])
+	}); err != nil {
+		return err
+	}
+
+	for uri, tool := range resourceTools {
+		if err := mcpServer.SetResourceTool(uri, tool); err != nil {
+			return err
+		}
+	}
+	return registerResourceWatchers(mcpServer, rancherClient)
+}
+
+// resourceTools maps each resource URI or template to the tool returning the
+// same data, so that a tool policy denying the tool also hides the resource
+var resourceTools = map[string]string{
+	"rancher://clusters":                                       "list_clusters",
+	"rancher://clusters/{name}":                                "get_cluster",
+	"rancher://projects":                                       "list_projects",
+	"rancher://projects/{namespace}":                           "list_projects",
+	"rancher://projects/{namespace}/{name}":                    "get_project",
+	"rancher://users":                                          "list_users",
+	"rancher://users/{name}":                                   "get_user",
+	"rancher://globalrolebindings":                             "list_global_role_bindings",
+	"rancher://globalrolebindings/{name}":                      "get_global_role_binding",
+	"rancher://clusterroletemplatebindings":                    "list_cluster_role_template_bindings",
+	"rancher://clusterroletemplatebindings/{namespace}/{name}": "get_cluster_role_template_binding",
+	"rancher://projectroletemplatebindings":                    "list_project_role_template_bindings",
+	"rancher://projectroletemplatebindings/{namespace}/{name}": "get_project_role_template_binding",
+}
+
+// watchPath builds the path of the collection to watch from the parameters
+// of a resource URI
+type watchPath func(params map[string]string) string
+
+// resourceWatches maps each subscribable resource URI or template to the
+// Kubernetes collection that backs it. Templates with a {name} variable watch
+// a single object.
+var resourceWatches = map[string]watchPath{
+	"rancher://clusters":                                       collection(client.ClustersPath),
+	"rancher://clusters/{name}":                                collection(client.ClustersPath),
+	"rancher://projects":                                       allNamespaces(client.ProjectsPath),
+	"rancher://projects/{namespace}":                           inNamespace(client.ProjectsPath),
+	"rancher://projects/{namespace}/{name}":                    inNamespace(client.ProjectsPath),
+	"rancher://users":                                          collection(client.UsersPath),
+	"rancher://users/{name}":                                   collection(client.UsersPath),
+	"rancher://globalrolebindings":                             collection(client.GlobalRoleBindingsPath),
+	"rancher://globalrolebindings/{name}":                      collection(client.GlobalRoleBindingsPath),
+	"rancher://clusterroletemplatebindings":                    allNamespaces(client.ClusterRoleTemplateBindingsPath),
+	"rancher://clusterroletemplatebindings/{namespace}/{name}": inNamespace(client.ClusterRoleTemplateBindingsPath),
+	"rancher://projectroletemplatebindings":                    allNamespaces(client.ProjectRoleTemplateBindingsPath),
+	"rancher://projectroletemplatebindings/{namespace}/{name}": inNamespace(client.ProjectRoleTemplateBindingsPath),
+}
+
+func collection(path func() string) watchPath {
+	return func(map[string]string) string { return path() }
+}
+
+func allNamespaces(path func(namespace string) string) watchPath {
+	return func(map[string]string) string { return path("") }
+}
+
+// inNamespace watches the collection in the URI's namespace, escaped so that
+// it stays a single path segment
+func inNamespace(path func(namespace string) string) watchPath {
+	return func(params map[string]string) string { return path(url.PathEscape(params["namespace"])) }
+}
+
+func registerResourceWatchers(mcpServer *mcp.Server, rancherClient *client.RancherClient) error {
+	for uri, path := range resourceWatches {
+		if err := mcpServer.SetResourceWatcher(uri, watchCollection(rancherClient, path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchCollection returns a watcher that notifies on every change to the
+// collection at path, narrowed to a single object when the URI has a name
+func watchCollection(rancherClient *client.RancherClient, path watchPath) mcp.ResourceWatcher {
+	return func(ctx context.Context, uri string, params map[string]string, notify func()) error {
+		// The watch runs as the caller whose subscription started it
+		rancherClient := clientFromContext(ctx, rancherClient)
+		if rancherClient == nil {
+			return fmt.Errorf("Rancher client not configured")
+		}
+
+		var opts client.WatchOptions
+		if name, ok := params["name"]; ok {
+			opts.FieldSelector = client.NameFieldSelector(name)
+		}
+
+		return rancherClient.Watch(ctx, path(params), opts, func(event client.WatchEvent) {
+			notify()
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// waitForRequest returns the first request the fake received, failing the
+// test if none arrives in time
+func (f *fakeRancher) waitForRequest(t *testing.T) *url.URL {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		requests := append([]string(nil), f.requests...)
+		f.mu.Unlock()
+		if len(requests) > 0 {
+			u, err := url.ParseRequestURI(requests[0])
+			if err != nil {
+				t.Fatalf("invalid request URI %q: %v", requests[0], err)
+			}
+			return u
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Rancher received no request")
+	return nil
+}
+
+func TestReadResources(t *testing.T) {
+	_, rancherClient := newFakeRancher(t, map[string]string{
+		client.ClustersPath():                      clusterListJSON,
+		client.ClusterPath("c-1"):                  clusterJSON,
+		client.ProjectsPath("c-1"):                 projectListJSON,
+		client.ProjectPath("p-default", "c-1"):     `{"metadata": {"name": "p-default", "namespace": "c-1"}}`,
+		client.ProjectRoleTemplateBindingsPath(""): `{"items": []}`,
+	})
+	s := mcp.NewServer("test", "1")
+	if err := RegisterResources(s, rancherClient); err != nil {
+		t.Fatalf("RegisterResources failed: %v", err)
+	}
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"rancher://clusters", `"name":"c-m-abc"`},
+		{"rancher://clusters/c-1", `"displayName":"prod"`},
+		{"rancher://projects/c-1", `"name":"p-system"`},
+		{"rancher://projects/c-1/p-default", `"namespace":"c-1"`},
+		{"rancher://projectroletemplatebindings", `"items":[]`},
+	}
+	for _, tt := range tests {
+		resp := request(context.Background(), s, "resources/read", map[string]interface{}{"uri": tt.uri})
+		if resp.Error != nil {
+			t.Errorf("read %s failed: %s", tt.uri, resp.Error.Message)
+			continue
+		}
+		contents := resp.Result.(mcp.ReadResourceResponse).Contents
+		if len(contents) != 1 || contents[0].URI != tt.uri {
+			t.Errorf("read %s = %+v, want one content for the URI", tt.uri, contents)
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(contents[0].Text)); err != nil {
+			t.Errorf("read %s returned invalid JSON: %v", tt.uri, err)
+		} else if !strings.Contains(compact.String(), tt.want) {
+			t.Errorf("read %s = %s, want it to contain %s", tt.uri, contents[0].Text, tt.want)
+		}
+	}
+
+	if resp := request(context.Background(), s, "resources/read", map[string]interface{}{"uri": "rancher://clusters/c-9"}); resp.Error == nil {
+		t.Error("read of a missing cluster succeeded")
+	}
+}
+
+func TestEveryWatchedResourceIsRegistered(t *testing.T) {
+	for uri := range resourceWatches {
+		if _, ok := resourceTools[uri]; !ok {
+			t.Errorf("%s is watched but has no tool", uri)
+		}
+	}
+	for uri := range resourceTools {
+		if _, ok := resourceWatches[uri]; !ok {
+			t.Errorf("%s has no watch", uri)
+		}
+	}
+}
+
+func TestWatchRequest(t *testing.T) {
+	tests := []struct {
+		template      string
+		params        map[string]string
+		path          string
+		fieldSelector string
+	}{
+		{"rancher://clusters", map[string]string{}, client.ClustersPath(), ""},
+		{"rancher://clusters/{name}", map[string]string{"name": "c-1"}, client.ClustersPath(), "metadata.name=c-1"},
+		{"rancher://projects", map[string]string{}, client.ProjectsPath(""), ""},
+		{"rancher://projects/{namespace}", map[string]string{"namespace": "c-1"}, client.ProjectsPath("c-1"), ""},
+		{
+			// Reserved characters stay inside the namespace segment and the
+			// selector value
+			"rancher://projects/{namespace}/{name}",
+			map[string]string{"namespace": "c 1?watch=false", "name": "p,metadata.namespace=x"},
+			client.ProjectsPath("c%201%3Fwatch=false"),
+			`metadata.name=p\,metadata.namespace\=x`,
+		},
+		{
+			"rancher://clusterroletemplatebindings/{namespace}/{name}",
+			map[string]string{"namespace": "c-1", "name": `b\1`},
+			client.ClusterRoleTemplateBindingsPath("c-1"),
+			`metadata.name=b\\1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			fake, rancherClient := newFakeRancher(t, nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				watchCollection(rancherClient, resourceWatches[tt.template])(ctx, tt.template, tt.params, func() {})
+			}()
+			defer func() {
+				cancel()
+				<-done
+			}()
+
+			u := fake.waitForRequest(t)
+			if u.EscapedPath() != tt.path {
+				t.Errorf("path = %s, want %s", u.EscapedPath(), tt.path)
+			}
+			if got := u.Query().Get("fieldSelector"); got != tt.fieldSelector {
+				t.Errorf("fieldSelector = %q, want %q", got, tt.fieldSelector)
+			}
+			if u.Query().Get("watch") != "" {
+				t.Errorf("query %s was changed by the parameters", u.RawQuery)
+			}
+		})
+	}
+}
@@ -13,12 +13,12 @@ func RegisterRoleStatusTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 	// GlobalRole status
 	mcpServer.RegisterToolWithSchema("get_global_role_status", "Get status of a specific global role", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the global role",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getGlobalRoleStatus(ctx, args, rancherClient)
@@ -27,12 +27,12 @@ func RegisterRoleStatusTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 	// GlobalRoleBinding status
 	mcpServer.RegisterToolWithSchema("get_global_role_binding_status", "Get status of a specific global role binding", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the global role binding",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getGlobalRoleBindingStatus(ctx, args, rancherClient)
@@ -41,12 +41,12 @@ func RegisterRoleStatusTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 	// RoleTemplate status
 	mcpServer.RegisterToolWithSchema("get_role_template_status", "Get status of a specific role template", map[string]interface{}{
 		"type": "object",
-		"properties": map[string]interface{}{
+		"properties": withOutputProperties(map[string]interface{}{
 			"name": map[string]interface{}{
 				"type":        "string",
 				"description": "The name of the role template",
 			},
-		},
+		}),
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return getRoleTemplateStatus(ctx, args, rancherClient)
@@ -54,34 +54,49 @@ func RegisterRoleStatusTools(mcpServer *mcp.Server, rancherClient *client.Ranche
 }
 
 func getGlobalRoleStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetGlobalRoleStatus(ctx, name)
+	return format.apply(rancherClient.GetGlobalRoleStatus(ctx, name))
 }
 
 func getGlobalRoleBindingStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetGlobalRoleBindingStatus(ctx, name)
+	return format.apply(rancherClient.GetGlobalRoleBindingStatus(ctx, name))
 }
 
 func getRoleTemplateStatus(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	format, err := resultFormatFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.GetRoleTemplateStatus(ctx, name)
+	return format.apply(rancherClient.GetRoleTemplateStatus(ctx, name))
 }
@@ -17,13 +17,14 @@ func RegisterRoleTemplateCreateUpdateTools(mcpServer *mcp.Server, rancherClient
 				"type":        "object",
 				"description": "Role template object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"template"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createRoleTemplate(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_role_template", "Update/replace a role template", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_role_template", "Update/replace a role template, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterRoleTemplateCreateUpdateTools(mcpServer *mcp.Server, rancherClient
 				"type":        "object",
 				"description": "Updated role template object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "template"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateRoleTemplate(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterRoleTemplateCreateUpdateTools(mcpServer *mcp.Server, rancherClient
 				"type":        "string",
 				"description": "The name of the role template",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterRoleTemplateCreateUpdateTools(mcpServer *mcp.Server, rancherClient
 }
 
 func createRoleTemplate(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	template, ok := args["template"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("template parameter is required and must be an object")
 	}
-	return rancherClient.CreateRoleTemplate(ctx, template)
+	return rancherClient.CreateRoleTemplate(ctx, template, opts)
 }
 
 func updateRoleTemplate(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "template")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.RoleTemplatePath(name), opts, mergeChanges(changes))
+	}
 	template, ok := args["template"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("template parameter is required and must be an object")
+		return nil, fmt.Errorf("template or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateRoleTemplate(ctx, name, template)
+	return rancherClient.UpdateRoleTemplate(ctx, name, template, opts)
 }
 
 func patchRoleTemplate(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchRoleTemplate(ctx context.Context, args map[string]interface{}, rancher
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchRoleTemplate(ctx, name, patch)
+	return rancherClient.PatchRoleTemplate(ctx, name, patch, opts)
 }
@@ -17,6 +17,7 @@ func RegisterRoleTemplateDeleteTools(mcpServer *mcp.Server, rancherClient *clien
 				"type":        "string",
 				"description": "The name of the role template to delete",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -25,12 +26,14 @@ func RegisterRoleTemplateDeleteTools(mcpServer *mcp.Server, rancherClient *clien
 }
 
 func deleteRoleTemplate(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	return rancherClient.DeleteRoleTemplate(ctx, name)
+	return rancherClient.DeleteRoleTemplate(ctx, name, opts)
 }
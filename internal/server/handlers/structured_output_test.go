@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const clusterJSON = `{
+  "apiVersion": "management.cattle.io/v3",
+  "kind": "Cluster",
+  "metadata": {"name": "c-1", "resourceVersion": "7", "labels": {"env": "prod"}},
+  "spec": {"displayName": "prod"},
+  "status": {"conditions": [{"type": "Ready", "status": "True"}]}
+}`
+
+// fakeRancher serves fixed bodies by request path and records the requests
+// it receives. Other paths get a 404 Status.
+type fakeRancher struct {
+	mu       sync.Mutex
+	requests []string // request URIs, escaped as sent
+}
+
+func newFakeRancher(t *testing.T, routes map[string]string) (*fakeRancher, *client.RancherClient) {
+	t.Helper()
+	fake := &fakeRancher{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		fake.requests = append(fake.requests, r.URL.RequestURI())
+		fake.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","status":"Failure","reason":"NotFound","code":404}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+	return fake, client.NewRancherClient(ts.URL, "token", false)
+}
+
+// newSession opens a session on s initialized with version
+func newSession(t *testing.T, s *mcp.Server, version string) context.Context {
+	t.Helper()
+	sess := s.NewSession(func(*mcp.JSONRPCNotification) error { return nil })
+	t.Cleanup(func() { s.CloseSession(sess.ID()) })
+	ctx := mcp.WithSession(context.Background(), sess)
+	resp := request(ctx, s, "initialize", map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "test", "version": "1"},
+	})
+	if resp.Error != nil {
+		t.Fatalf("initialize failed: %s", resp.Error.Message)
+	}
+	return ctx
+}
+
+func request(ctx context.Context, s *mcp.Server, method string, params map[string]interface{}) *mcp.JSONRPCResponse {
+	return s.HandleRequest(ctx, &mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+}
+
+// callTool calls a tool in the session of ctx and returns its result
+func callTool(t *testing.T, ctx context.Context, s *mcp.Server, name string, args map[string]interface{}) mcp.CallToolResponse {
+	t.Helper()
+	resp := request(ctx, s, "tools/call", map[string]interface{}{"name": name, "arguments": args})
+	if resp.Error != nil {
+		t.Fatalf("%s failed: %s", name, resp.Error.Message)
+	}
+	result := resp.Result.(mcp.CallToolResponse)
+	if result.IsError {
+		t.Fatalf("%s returned an error: %s", name, result.Content[0].Text)
+	}
+	return result
+}
+
+// checkSchema reports where value does not match the types, properties,
+// required fields and array items of schema
+func checkSchema(t *testing.T, schema map[string]interface{}, value interface{}, path string) {
+	t.Helper()
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			t.Errorf("%s = %v, want an object", path, value)
+			return
+		}
+		required, _ := schema["required"].([]string)
+		for _, field := range required {
+			if _, ok := obj[field]; !ok {
+				t.Errorf("%s.%s is missing", path, field)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for field, propertySchema := range properties {
+			if fieldValue, ok := obj[field]; ok {
+				checkSchema(t, propertySchema.(map[string]interface{}), fieldValue, path+"."+field)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			t.Errorf("%s = %v, want an array", path, value)
+			return
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		for _, item := range items {
+			checkSchema(t, itemSchema, item, path+"[]")
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			t.Errorf("%s = %v, want a string", path, value)
+		}
+	}
+}
+
+func newClusterToolServer(t *testing.T) *mcp.Server {
+	t.Helper()
+	_, rancherClient := newFakeRancher(t, map[string]string{
+		client.ClustersPath():     clusterListJSON,
+		client.ClusterPath("c-1"): clusterJSON,
+	})
+	s := mcp.NewServer("test", "1")
+	RegisterClusterTools(s, rancherClient)
+	ApplyToolMetadata(s)
+	return s
+}
+
+func TestStructuredContent(t *testing.T) {
+	s := newClusterToolServer(t)
+	ctx := newSession(t, s, mcp.ProtocolVersion20250618)
+
+	tests := []struct {
+		tool string
+		args map[string]interface{}
+	}{
+		{"get_cluster", map[string]interface{}{"name": "c-1"}},
+		{"list_clusters", map[string]interface{}{}},
+		{"get_cluster", map[string]interface{}{"name": "c-1", "output": "yaml"}},
+		{"list_clusters", map[string]interface{}{"output": "table"}},
+	}
+	for _, tt := range tests {
+		result := callTool(t, ctx, s, tt.tool, tt.args)
+		if result.StructuredContent == nil {
+			t.Errorf("%s %v has no structuredContent", tt.tool, tt.args)
+			continue
+		}
+		checkSchema(t, toolOutputSchema(tt.tool), result.StructuredContent, tt.tool)
+	}
+
+	// A yaml or table result carries the object, not its text
+	result := callTool(t, ctx, s, "list_clusters", map[string]interface{}{"output": "table"})
+	if strings.HasPrefix(result.Content[0].Text, "{") {
+		t.Errorf("table text = %q, want a table", result.Content[0].Text)
+	}
+	if items, _ := result.StructuredContent.(map[string]interface{})["items"].([]interface{}); len(items) != 2 {
+		t.Errorf("table structuredContent = %v, want both clusters", result.StructuredContent)
+	}
+}
+
+func TestNoStructuredContentForOlderClients(t *testing.T) {
+	s := newClusterToolServer(t)
+	for _, version := range []string{mcp.ProtocolVersion20250326, mcp.ProtocolVersion20241105} {
+		ctx := newSession(t, s, version)
+		for _, output := range []string{"json", "yaml"} {
+			result := callTool(t, ctx, s, "get_cluster", map[string]interface{}{"name": "c-1", "output": output})
+			if result.StructuredContent != nil {
+				t.Errorf("%s %s: structuredContent = %v, want none", version, output, result.StructuredContent)
+			}
+			if result.Content[0].Text == "" {
+				t.Errorf("%s %s: no text content", version, output)
+			}
+		}
+	}
+}
@@ -17,13 +17,14 @@ func RegisterTokenCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client
 				"type":        "object",
 				"description": "Token object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"token"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createToken(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_token", "Update/replace an API token", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_token", "Update/replace an API token, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterTokenCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client
 				"type":        "object",
 				"description": "Updated token object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "token"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateToken(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterTokenCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client
 				"type":        "string",
 				"description": "The name of the token",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterTokenCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client
 }
 
 func createToken(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	token, ok := args["token"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("token parameter is required and must be an object")
 	}
-	return rancherClient.CreateToken(ctx, token)
+	return rancherClient.CreateToken(ctx, token, opts)
 }
 
 func updateToken(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "token")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.TokenPath(name), opts, mergeChanges(changes))
+	}
 	token, ok := args["token"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("token parameter is required and must be an object")
+		return nil, fmt.Errorf("token or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateToken(ctx, name, token)
+	return rancherClient.UpdateToken(ctx, name, token, opts)
 }
 
 func patchToken(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchToken(ctx context.Context, args map[string]interface{}, rancherClient
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchToken(ctx, name, patch)
+	return rancherClient.PatchToken(ctx, name, patch, opts)
 }
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// toolBehavior holds the annotation hints shared by every tool with a given
+// verb prefix
+type toolBehavior struct {
+	readOnly    bool
+	destructive bool
+	idempotent  bool
+}
+
+// toolBehaviors maps a tool name's verb prefix to its behavior. Updates and
+// applies replace existing state, so they are destructive but can be
+// repeated safely; creates only add state but are not idempotent. Patches
+// are not idempotent either, because a JSON patch can append to an array.
+var toolBehaviors = map[string]toolBehavior{
+	"list":   {readOnly: true},
+	"get":    {readOnly: true},
+	"wait":   {readOnly: true},
+	"create": {},
+	"update": {destructive: true, idempotent: true},
+	"patch":  {destructive: true},
+	"apply":  {destructive: true, idempotent: true},
+	"delete": {destructive: true, idempotent: true},
+}
+
+// toolCategories maps a fragment of a tool name to the category clients can
+// filter tools/list by. The first match wins, so every binding and role tool
+// lands in rbac before the cluster and project fragments are tried.
+// apply_resource takes objects of any kind, so it has a category of its own.
+var toolCategories = []struct {
+	fragment string
+	category string
+}{
+	{"audit_polic", "audit"},
+	{"role", "rbac"},
+	{"token", "tokens"},
+	{"kubeconfig", "tokens"},
+	{"project", "project"},
+	{"user", "user"},
+	{"cluster", "cluster"},
+	{"apply_resource", "resources"},
+}
+
+func toolCategory(name string) string {
+	for _, c := range toolCategories {
+		if strings.Contains(name, c.fragment) {
+			return c.category
+		}
+	}
+	return ""
+}
+
+// objectMetaSchema describes the Kubernetes metadata fields clients most
+// often read; other fields are allowed
+var objectMetaSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"name":            map[string]interface{}{"type": "string"},
+		"namespace":       map[string]interface{}{"type": "string"},
+		"uid":             map[string]interface{}{"type": "string"},
+		"resourceVersion": map[string]interface{}{"type": "string"},
+		"labels":          map[string]interface{}{"type": "object"},
+		"annotations":     map[string]interface{}{"type": "object"},
+	},
+}
+
+// resourceOutputSchema describes a single Kubernetes object as returned by
+// the get, create, update, patch and apply tools
+var resourceOutputSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"apiVersion": map[string]interface{}{"type": "string"},
+		"kind":       map[string]interface{}{"type": "string"},
+		"metadata":   objectMetaSchema,
+		"spec":       map[string]interface{}{"type": "object"},
+		"status":     map[string]interface{}{"type": "object"},
+	},
+}
+
+// listOutputSchema describes a Kubernetes list as returned by the list tools
+var listOutputSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"apiVersion": map[string]interface{}{"type": "string"},
+		"kind":       map[string]interface{}{"type": "string"},
+		"metadata": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"resourceVersion": map[string]interface{}{"type": "string"},
+				"continue":        map[string]interface{}{"type": "string"},
+			},
+		},
+		"items": map[string]interface{}{
+			"type":  "array",
+			"items": resourceOutputSchema,
+		},
+		// Copied from metadata.continue when the list was cut off by limit
+		"continue": map[string]interface{}{"type": "string"},
+	},
+	"required": []string{"items"},
+}
+
+// toolOutputSchema picks the output schema for a tool. Status, wait and
+// delete tools return ad hoc shapes, so they declare none.
+func toolOutputSchema(name string) map[string]interface{} {
+	verb, _, _ := strings.Cut(name, "_")
+	switch {
+	case verb == "list":
+		return listOutputSchema
+	case strings.HasSuffix(name, "_status"):
+		return nil
+	case verb == "get", verb == "create", verb == "update", verb == "patch", verb == "apply":
+		return resourceOutputSchema
+	default:
+		return nil
+	}
+}
+
+// ApplyToolMetadata sets a title, annotation hints, a category and, where the
+// result has a known shape, an output schema on every registered tool. It must run after
+// all tools are registered.
+func ApplyToolMetadata(mcpServer *mcp.Server) {
+	for _, name := range mcpServer.ToolNames() {
+		verb, _, _ := strings.Cut(name, "_")
+		behavior, ok := toolBehaviors[verb]
+		if !ok {
+			logrus.Warnf("No annotations defined for tool %s", name)
+			continue
+		}
+
+		title := toolTitle(name)
+		annotations := &mcp.ToolAnnotations{
+			Title:        title,
+			ReadOnlyHint: boolPtr(behavior.readOnly),
+			// Every tool acts on a single Rancher Manager, not an open world
+			OpenWorldHint: boolPtr(false),
+		}
+		// The destructive and idempotent hints are only meaningful for tools
+		// that modify state
+		if !behavior.readOnly {
+			annotations.DestructiveHint = boolPtr(behavior.destructive)
+			annotations.IdempotentHint = boolPtr(behavior.idempotent)
+		}
+
+		if err := mcpServer.SetToolAnnotations(name, title, annotations); err != nil {
+			logrus.Warnf("Failed to annotate tool %s: %v", name, err)
+		}
+		if category := toolCategory(name); category != "" {
+			if err := mcpServer.SetToolCategories(name, category); err != nil {
+				logrus.Warnf("Failed to categorize tool %s: %v", name, err)
+			}
+		}
+		if schema := toolOutputSchema(name); schema != nil {
+			if err := mcpServer.SetToolOutputSchema(name, schema); err != nil {
+				logrus.Warnf("Failed to set output schema for tool %s: %v", name, err)
+			}
+		}
+	}
+}
+
+// toolTitle turns a tool name such as get_cluster_status into "Get Cluster Status"
+func toolTitle(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
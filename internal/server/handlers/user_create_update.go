@@ -17,13 +17,14 @@ func RegisterUserCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client.
 				"type":        "object",
 				"description": "User object to create",
 			},
+			"dry_run": dryRunProperty(),
 		},
 		"required": []string{"user"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return createUser(ctx, args, rancherClient)
 	})
 
-	mcpServer.RegisterToolWithSchema("update_user", "Update/replace a user", map[string]interface{}{
+	mcpServer.RegisterToolWithSchema("update_user", "Update/replace a user, or change some of its fields", map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"name": map[string]interface{}{
@@ -34,8 +35,10 @@ func RegisterUserCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client.
 				"type":        "object",
 				"description": "Updated user object",
 			},
+			"changes": changesProperty(),
+			"dry_run": dryRunProperty(),
 		},
-		"required": []string{"name", "user"},
+		"required": []string{"name"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 		return updateUser(ctx, args, rancherClient)
 	})
@@ -47,10 +50,10 @@ func RegisterUserCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client.
 				"type":        "string",
 				"description": "The name or ID of the user",
 			},
-			"patch": map[string]interface{}{
-				"type":        "object",
-				"description": "Partial update patch object",
-			},
+			"patch":      patchProperty(),
+			"patch_type": patchTypeProperty(),
+			"force":      forceProperty(),
+			"dry_run":    dryRunProperty(),
 		},
 		"required": []string{"name", "patch"},
 	}, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -59,32 +62,44 @@ func RegisterUserCreateUpdateTools(mcpServer *mcp.Server, rancherClient *client.
 }
 
 func createUser(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	user, ok := args["user"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("user parameter is required and must be an object")
 	}
-	return rancherClient.CreateUser(ctx, user)
+	return rancherClient.CreateUser(ctx, user, opts)
 }
 
 func updateUser(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
+	opts := writeOptionsFromArgs(args)
 	name, ok := args["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
+	changes, err := changesFromArgs(args, "user")
+	if err != nil {
+		return nil, err
+	}
+	if changes != nil {
+		return rancherClient.UpdateWithRetry(ctx, client.UserPath(name), opts, mergeChanges(changes))
+	}
 	user, ok := args["user"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("user parameter is required and must be an object")
+		return nil, fmt.Errorf("user or changes parameter is required and must be an object")
 	}
-	return rancherClient.UpdateUser(ctx, name, user)
+	return rancherClient.UpdateUser(ctx, name, user, opts)
 }
 
 func patchUser(ctx context.Context, args map[string]interface{}, rancherClient *client.RancherClient) (interface{}, error) {
+	rancherClient = clientFromContext(ctx, rancherClient)
 	if rancherClient == nil {
 		return nil, fmt.Errorf("Rancher client not configured")
 	}
@@ -92,9 +107,9 @@ func patchUser(ctx context.Context, args map[string]interface{}, rancherClient *
 	if !ok {
 		return nil, fmt.Errorf("name parameter is required")
 	}
-	patch, ok := args["patch"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("patch parameter is required and must be an object")
+	opts, patch, err := patchFromArgs(args)
+	if err != nil {
+		return nil, err
 	}
-	return rancherClient.PatchUser(ctx, name, patch)
+	return rancherClient.PatchUser(ctx, name, patch, opts)
 }
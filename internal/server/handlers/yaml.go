@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// marshalYAML renders a decoded JSON value as block-style YAML, with map keys
+// sorted. Strings that a YAML parser could read as another type are quoted.
+func marshalYAML(value interface{}) string {
+	var b strings.Builder
+	writeYAML(&b, value, 0)
+	return b.String()
+}
+
+func writeYAML(b *strings.Builder, value interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString(pad + "{}\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			b.WriteString(pad + yamlKey(key) + ":")
+			writeYAMLValue(b, v[key], indent, indent+1)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString(pad + "[]\n")
+			return
+		}
+		for _, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok && len(obj) > 0 {
+				// The first key of a map item shares the line with its dash
+				var nested strings.Builder
+				writeYAML(&nested, obj, indent+1)
+				b.WriteString(pad + "- " + nested.String()[len(pad)+2:])
+				continue
+			}
+			b.WriteString(pad + "-")
+			writeYAMLValue(b, item, indent, indent+1)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(v, indent) + "\n")
+	}
+}
+
+// writeYAMLValue writes the value after a "key:" or "-". Sequences under a key
+// stay at the key's indent, as kubectl prints them.
+func writeYAMLValue(b *strings.Builder, value interface{}, listIndent, mapIndent int) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			b.WriteString("\n")
+			writeYAML(b, v, mapIndent)
+			return
+		}
+		b.WriteString(" {}\n")
+	case []interface{}:
+		if len(v) > 0 {
+			b.WriteString("\n")
+			writeYAML(b, v, listIndent)
+			return
+		}
+		b.WriteString(" []\n")
+	default:
+		b.WriteString(" " + yamlScalar(v, mapIndent) + "\n")
+	}
+}
+
+// yamlScalar formats a scalar. indent is the level at which the lines of a
+// multi-line string are written.
+func yamlScalar(value interface{}, indent int) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return yamlString(v, indent)
+	default:
+		return yamlString(fmt.Sprint(v), indent)
+	}
+}
+
+func yamlString(s string, indent int) string {
+	if block, ok := yamlBlockString(s, indent); ok {
+		return block
+	}
+	return yamlKey(s)
+}
+
+// yamlKey formats a string on a single line
+func yamlKey(s string) string {
+	if yamlPlainSafe(s) {
+		return s
+	}
+	// A JSON string is a valid YAML double-quoted scalar
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// yamlBlockString writes multi-line strings, such as certificates, as literal
+// blocks
+func yamlBlockString(s string, indent int) (string, bool) {
+	body := strings.TrimSuffix(s, "\n")
+	if !strings.Contains(body, "\n") || strings.HasSuffix(body, "\n") ||
+		strings.HasPrefix(body, " ") || strings.ContainsAny(body, "\r\t") {
+		return "", false
+	}
+	header := "|-"
+	if len(body) < len(s) {
+		header = "|"
+	}
+	pad := strings.Repeat("  ", indent)
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return header + "\n" + strings.Join(lines, "\n"), true
+}
+
+// yamlPlainSafe reports whether s can be written unquoted and still read
+// back as the same string
+func yamlPlainSafe(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return false
+	}
+	first := s[0]
+	if !(first >= 'a' && first <= 'z' || first >= 'A' && first <= 'Z' || first == '_' || first == '/') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("_-./@=+ ", c) >= 0:
+		case c == ':' && i+1 < len(s) && s[i+1] != ' ':
+		default:
+			return false
+		}
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+const (
+	sessionHeader       = "Mcp-Session-Id"
+	protocolHeader      = "MCP-Protocol-Version"
+	sessionIdleTimeout  = 30 * time.Minute
+	sessionReapInterval = time.Minute
+	sessionQueueSize    = 64
+	sseKeepAlive        = 25 * time.Second
+	eventStreamMimeType = "text/event-stream"
+)
+
+// httpSession is the HTTP side of an MCP session. Server-initiated messages
+// are queued until the client opens a GET stream to receive them.
+type httpSession struct {
+	session *mcp.Session
+	out     chan []byte
+	// owner identifies the caller that created the session when callers
+	// bring their own credentials; other callers may not use it
+	owner string
+
+	mu        sync.Mutex
+	streaming bool
+	lastSeen  time.Time
+}
+
+func (hs *httpSession) touch() {
+	hs.mu.Lock()
+	hs.lastSeen = time.Now()
+	hs.mu.Unlock()
+}
+
+func (hs *httpSession) idleSince(now time.Time) time.Duration {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.streaming {
+		return 0
+	}
+	return now.Sub(hs.lastSeen)
+}
+
+// attachStream claims the session's GET stream; only one may be open at a time
+func (hs *httpSession) attachStream() bool {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.streaming {
+		return false
+	}
+	hs.streaming = true
+	return true
+}
+
+func (hs *httpSession) detachStream() {
+	hs.mu.Lock()
+	hs.streaming = false
+	hs.lastSeen = time.Now()
+	hs.mu.Unlock()
+}
+
+func (s *Server) newHTTPSession(owner string) *httpSession {
+	hs := &httpSession{
+		out:      make(chan []byte, sessionQueueSize),
+		owner:    owner,
+		lastSeen: time.Now(),
+	}
+	hs.session = s.mcpServer.NewSession(func(n *mcp.JSONRPCNotification) error {
+		data, err := json.Marshal(n)
+		if err != nil {
+			return err
+		}
+		select {
+		case hs.out <- data:
+			return nil
+		default:
+			return fmt.Errorf("session %s: message queue full", hs.session.ID())
+		}
+	})
+
+	s.sessionsMu.Lock()
+	s.httpSessions[hs.session.ID()] = hs
+	s.sessionsMu.Unlock()
+
+	return hs
+}
+
+// lookupHTTPSession validates the Mcp-Session-Id and MCP-Protocol-Version
+// headers of r. Clients on revisions before 2025-06-18 do not send the
+// protocol header, so its absence is accepted.
+func (s *Server) lookupHTTPSession(r *http.Request) (*httpSession, int, string) {
+	id := r.Header.Get(sessionHeader)
+	if id == "" {
+		return nil, http.StatusBadRequest, "Missing Mcp-Session-Id header"
+	}
+	if version := r.Header.Get(protocolHeader); version != "" && !mcp.IsSupportedProtocolVersion(version) {
+		return nil, http.StatusBadRequest, fmt.Sprintf("Unsupported %s: %s", protocolHeader, version)
+	}
+
+	s.sessionsMu.Lock()
+	hs, ok := s.httpSessions[id]
+	s.sessionsMu.Unlock()
+	if !ok {
+		// 404 tells the client to start a new session with initialize
+		return nil, http.StatusNotFound, "Unknown or expired session"
+	}
+	if hs.owner != s.callerIdentity(r) {
+		return nil, http.StatusForbidden, "Session belongs to a different caller"
+	}
+
+	hs.touch()
+	return hs, 0, ""
+}
+
+func (s *Server) closeHTTPSession(id string) bool {
+	s.sessionsMu.Lock()
+	_, ok := s.httpSessions[id]
+	delete(s.httpSessions, id)
+	s.sessionsMu.Unlock()
+
+	if ok {
+		s.mcpServer.CloseSession(id)
+	}
+	return ok
+}
+
+// ReapIdleSessions closes HTTP sessions that were abandoned without a DELETE,
+// along with their subscriptions and Rancher watch streams. It checks every
+// minute until ctx is done.
+func (s *Server) ReapIdleSessions(ctx context.Context) {
+	s.reapIdleSessionsEvery(ctx, sessionReapInterval)
+}
+
+func (s *Server) reapIdleSessionsEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reapIdleSessions()
+		}
+	}
+}
+
+// reapIdleSessions closes sessions idle for longer than sessionIdleTimeout
+func (s *Server) reapIdleSessions() {
+	now := time.Now()
+
+	s.sessionsMu.Lock()
+	var expired []string
+	for id, hs := range s.httpSessions {
+		if hs.idleSince(now) > sessionIdleTimeout {
+			expired = append(expired, id)
+		}
+	}
+	s.sessionsMu.Unlock()
+
+	for _, id := range expired {
+		s.closeHTTPSession(id)
+	}
+}
+
+// handleMCPStream serves GET /mcp, the SSE stream for server-initiated messages
+func (s *Server) handleMCPStream(w http.ResponseWriter, r *http.Request) {
+	if !acceptsEventStream(r) {
+		http.Error(w, "Accept must include text/event-stream", http.StatusNotAcceptable)
+		return
+	}
+
+	if s.callerClients != nil && s.callerToken(r) == "" {
+		s.writeUnauthorized(w)
+		return
+	}
+
+	hs, status, message := s.lookupHTTPSession(r)
+	if hs == nil {
+		http.Error(w, message, status)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	if !hs.attachStream() {
+		http.Error(w, "A stream is already open for this session", http.StatusConflict)
+		return
+	}
+	defer hs.detachStream()
+
+	w.Header().Set("Content-Type", eventStreamMimeType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-hs.out:
+			if err := writeSSEEvent(w, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// handleMCPDelete serves DELETE /mcp, which ends a session
+func (s *Server) handleMCPDelete(w http.ResponseWriter, r *http.Request) {
+	if s.callerClients != nil && s.callerToken(r) == "" {
+		s.writeUnauthorized(w)
+		return
+	}
+
+	hs, status, message := s.lookupHTTPSession(r)
+	if hs == nil {
+		http.Error(w, message, status)
+		return
+	}
+	if !s.closeHTTPSession(hs.session.ID()) {
+		http.Error(w, "Unknown or expired session", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sseResponse writes a POST response as plain JSON, upgrading to an SSE
+// stream the first time the request emits a notification of its own
+type sseResponse struct {
+	w http.ResponseWriter
+
+	mu       sync.Mutex
+	upgraded bool
+	done     bool
+}
+
+func (sr *sseResponse) notify(n *mcp.JSONRPCNotification) error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sr.done {
+		return fmt.Errorf("response already sent")
+	}
+	if !sr.upgraded {
+		sr.w.Header().Set("Content-Type", eventStreamMimeType)
+		sr.w.Header().Set("Cache-Control", "no-cache")
+		sr.w.WriteHeader(http.StatusOK)
+		sr.upgraded = true
+	}
+	if err := writeSSEEvent(sr.w, data); err != nil {
+		return err
+	}
+	if flusher, ok := sr.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (sr *sseResponse) finish(response interface{}) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	sr.done = true
+	if !sr.upgraded {
+		sr.w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(sr.w).Encode(response)
+		return
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	writeSSEEvent(sr.w, data)
+	if flusher, ok := sr.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	return err
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+			if mediaType == eventStreamMimeType || mediaType == "*/*" {
+				return true
+			}
+		}
+	}
+	return false
+}
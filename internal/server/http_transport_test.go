@@ -0,0 +1,194 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initializeBody(version string) string {
+	return `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"` + version +
+		`","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+}
+
+// newTestHTTPServer serves s over HTTP for the duration of the test
+func newTestHTTPServer(t *testing.T, opts Options) (*Server, *httptest.Server) {
+	t.Helper()
+	s := NewServerWithOptions(opts)
+	ts := httptest.NewServer(s.HTTPHandler())
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+// mcpRequest sends a request to the MCP endpoint. headers are set in pairs
+// of name and value.
+func mcpRequest(t *testing.T, ts *httptest.Server, method, sessionID, body string, headers ...string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+"/mcp", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set(sessionHeader, sessionID)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// initializeSession runs the initialize handshake for the latest protocol
+// version and returns the session ID
+func initializeSession(t *testing.T, ts *httptest.Server, headers ...string) string {
+	t.Helper()
+	return initializeSessionVersion(t, ts, "2025-06-18", headers...)
+}
+
+func initializeSessionVersion(t *testing.T, ts *httptest.Server, version string, headers ...string) string {
+	t.Helper()
+	resp := mcpRequest(t, ts, http.MethodPost, "", initializeBody(version), headers...)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("initialize returned %d", resp.StatusCode)
+	}
+	id := resp.Header.Get(sessionHeader)
+	if id == "" {
+		t.Fatal("initialize returned no session ID")
+	}
+	resp = mcpRequest(t, ts, http.MethodPost, id, `{"jsonrpc":"2.0","method":"notifications/initialized"}`, headers...)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("notifications/initialized returned %d", resp.StatusCode)
+	}
+	return id
+}
+
+// decodeResponse decodes a JSON-RPC response body
+func decodeResponse(t *testing.T, resp *http.Response) map[string]interface{} {
+	t.Helper()
+	var msg map[string]interface{}
+	if err := jsonDecode(resp, &msg); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return msg
+}
+
+func jsonDecode(resp *http.Response, v interface{}) error {
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func TestHTTPSessionRequired(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	body := `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`
+
+	if resp := mcpRequest(t, ts, http.MethodPost, "", body); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("POST without a session returned %d, want 400", resp.StatusCode)
+	}
+	resp := mcpRequest(t, ts, http.MethodPost, "unknown", body)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("POST with an unknown session returned %d, want 404", resp.StatusCode)
+	}
+	if msg := decodeResponse(t, resp); msg["error"] == nil {
+		t.Errorf("response = %v, want a JSON-RPC error", msg)
+	}
+}
+
+func TestHTTPDeleteSession(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	if resp := mcpRequest(t, ts, http.MethodDelete, id, ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE returned %d, want 204", resp.StatusCode)
+	}
+	if _, ok := s.mcpServer.Session(id); ok {
+		t.Error("session is still registered after DELETE")
+	}
+	if resp := mcpRequest(t, ts, http.MethodPost, id, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("POST after DELETE returned %d, want 404", resp.StatusCode)
+	}
+	if resp := mcpRequest(t, ts, http.MethodDelete, id, ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("second DELETE returned %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestHTTPStream(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	if resp := mcpRequest(t, ts, http.MethodGet, id, ""); resp.StatusCode != http.StatusNotAcceptable {
+		t.Errorf("GET without Accept: text/event-stream returned %d, want 406", resp.StatusCode)
+	}
+
+	stream := mcpRequest(t, ts, http.MethodGet, id, "", "Accept", eventStreamMimeType)
+	if stream.StatusCode != http.StatusOK || stream.Header.Get("Content-Type") != eventStreamMimeType {
+		t.Fatalf("GET returned %d %s, want an event stream", stream.StatusCode, stream.Header.Get("Content-Type"))
+	}
+	if resp := mcpRequest(t, ts, http.MethodGet, id, "", "Accept", eventStreamMimeType); resp.StatusCode != http.StatusConflict {
+		t.Errorf("second GET returned %d, want 409", resp.StatusCode)
+	}
+
+	sess, _ := s.mcpServer.Session(id)
+	if err := sess.Notify("notifications/message", map[string]interface{}{"level": "info", "data": "hello"}); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(stream.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("stream closed before the notification arrived")
+			}
+			if strings.HasPrefix(line, "data: ") && strings.Contains(line, `"notifications/message"`) {
+				return
+			}
+		case <-timeout:
+			t.Fatal("notification was not delivered on the stream")
+		}
+	}
+}
+
+func TestReapIdleSessions(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{})
+	idle := initializeSession(t, ts)
+	active := initializeSession(t, ts)
+
+	s.sessionsMu.Lock()
+	s.httpSessions[idle].lastSeen = time.Now().Add(-2 * sessionIdleTimeout)
+	s.sessionsMu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.reapIdleSessionsEvery(ctx, 10*time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := s.mcpServer.Session(idle); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("idle session was not reaped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok := s.mcpServer.Session(active); !ok {
+		t.Error("active session was reaped")
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+)
+
+// incomingMessage is one element of a POST body. Besides requests and
+// notifications, clients may POST responses to server-initiated requests.
+type incomingMessage struct {
+	mcp.JSONRPCRequest
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+
+	// invalid is set when the element is not a valid JSON-RPC message
+	invalid *mcp.JSONRPCError
+}
+
+// parseJSONRPCBody decodes a single message or a batch. A non-nil error is a
+// failure of the body as a whole; problems with individual batch elements are
+// recorded on the element instead.
+func parseJSONRPCBody(body []byte) ([]*incomingMessage, bool, *mcp.JSONRPCError) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, false, &mcp.JSONRPCError{Code: -32700, Message: "Parse error: empty body"}
+	}
+
+	if trimmed[0] != '[' {
+		msg, err := parseJSONRPCMessage(trimmed)
+		if err != nil {
+			return nil, false, err
+		}
+		return []*incomingMessage{msg}, false, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, true, &mcp.JSONRPCError{Code: -32700, Message: "Parse error: " + err.Error()}
+	}
+	if len(raw) == 0 {
+		return nil, true, &mcp.JSONRPCError{Code: -32600, Message: "Invalid Request: empty batch"}
+	}
+
+	messages := make([]*incomingMessage, 0, len(raw))
+	for _, element := range raw {
+		msg, err := parseJSONRPCMessage(element)
+		if err != nil {
+			// Invalid elements still get a response, with a null ID
+			msg = &incomingMessage{invalid: &mcp.JSONRPCError{Code: -32600, Message: err.Message}}
+		}
+		messages = append(messages, msg)
+	}
+	return messages, true, nil
+}
+
+func parseJSONRPCMessage(data []byte) (*incomingMessage, *mcp.JSONRPCError) {
+	var msg incomingMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		if _, ok := err.(*json.SyntaxError); ok {
+			return nil, &mcp.JSONRPCError{Code: -32700, Message: "Parse error: " + err.Error()}
+		}
+		return nil, &mcp.JSONRPCError{Code: -32600, Message: "Invalid Request: " + err.Error()}
+	}
+
+	if msg.JSONRPC != "2.0" {
+		msg.invalid = &mcp.JSONRPCError{Code: -32600, Message: "Invalid Request: jsonrpc must be \"2.0\""}
+	} else if msg.Method == "" && (msg.ID == nil || (msg.Result == nil && msg.Error == nil)) {
+		msg.invalid = &mcp.JSONRPCError{Code: -32600, Message: "Invalid Request: method is required"}
+	}
+	return &msg, nil
+}
+
+// dispatchBatch handles every message concurrently and returns the responses
+// in request order. Notifications and client responses produce no entry.
+func (s *Server) dispatchBatch(ctx context.Context, messages []*incomingMessage) []*mcp.JSONRPCResponse {
+	results := make([]*mcp.JSONRPCResponse, len(messages))
+
+	var wg sync.WaitGroup
+	for i, msg := range messages {
+		wg.Add(1)
+		go func(i int, msg *incomingMessage) {
+			defer wg.Done()
+			results[i] = s.dispatchMessage(ctx, msg)
+		}(i, msg)
+	}
+	wg.Wait()
+
+	responses := make([]*mcp.JSONRPCResponse, 0, len(results))
+	for _, resp := range results {
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	return responses
+}
+
+// dispatchMessage handles one message, returning nil when no response is due
+func (s *Server) dispatchMessage(ctx context.Context, msg *incomingMessage) *mcp.JSONRPCResponse {
+	if msg.invalid != nil {
+		return &mcp.JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      msg.ID,
+			Error:   msg.invalid,
+		}
+	}
+	if msg.Method == "" {
+		// A response to a server-initiated request; nothing is waiting on it yet
+		return nil
+	}
+	if msg.Method == "initialize" {
+		return &mcp.JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      msg.ID,
+			Error: &mcp.JSONRPCError{
+				Code:    -32600,
+				Message: "Invalid Request: initialize must not be part of a batch",
+			},
+		}
+	}
+	return s.mcpServer.HandleRequest(ctx, &msg.JSONRPCRequest)
+}
+
+// writeJSONRPCError writes a standalone JSON-RPC error with the given HTTP status
+func writeJSONRPCError(w http.ResponseWriter, status int, id interface{}, rpcErr *mcp.JSONRPCError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&mcp.JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   rpcErr,
+	})
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHTTPInitializeWithoutID(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{})
+
+	resp := mcpRequest(t, ts, http.MethodPost, "", `{"jsonrpc":"2.0","method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("initialize without an id returned %d, want 400", resp.StatusCode)
+	}
+	if resp.Header.Get(sessionHeader) != "" {
+		t.Error("initialize without an id returned a session ID")
+	}
+	msg := decodeResponse(t, resp)
+	if rpcErr, _ := msg["error"].(map[string]interface{}); rpcErr == nil || rpcErr["code"] != float64(-32600) {
+		t.Errorf("response = %v, want a -32600 error", msg)
+	}
+
+	s.sessionsMu.Lock()
+	sessions := len(s.httpSessions)
+	s.sessionsMu.Unlock()
+	if sessions != 0 {
+		t.Errorf("%d sessions are open, want none", sessions)
+	}
+}
+
+func TestHTTPInitializeError(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{})
+
+	resp := mcpRequest(t, ts, http.MethodPost, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":"not an object"}`)
+	if msg := decodeResponse(t, resp); msg["error"] == nil {
+		t.Fatalf("response = %v, want an error", msg)
+	}
+	if resp.Header.Get(sessionHeader) != "" {
+		t.Error("failed initialize returned a session ID")
+	}
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	if len(s.httpSessions) != 0 {
+		t.Errorf("%d sessions are open after a failed initialize, want none", len(s.httpSessions))
+	}
+}
+
+func TestHTTPNotification(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	resp := mcpRequest(t, ts, http.MethodPost, id, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":99}}`)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification returned %d, want 202", resp.StatusCode)
+	}
+}
+
+func TestHTTPBatch(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSessionVersion(t, ts, "2025-03-26")
+
+	resp := mcpRequest(t, ts, http.MethodPost, id, `[
+		{"jsonrpc":"2.0","id":"a","method":"prompts/list"},
+		{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":99}},
+		{"jsonrpc":"1.0","id":"b","method":"prompts/list"},
+		{"jsonrpc":"2.0","id":"c","method":"initialize"}
+	]`)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("batch returned %d, want 200", resp.StatusCode)
+	}
+	var responses []map[string]interface{}
+	if err := jsonDecode(resp, &responses); err != nil {
+		t.Fatalf("failed to decode batch response: %v", err)
+	}
+	if len(responses) != 3 {
+		t.Fatalf("batch returned %d responses, want 3 (none for the notification): %v", len(responses), responses)
+	}
+	if responses[0]["id"] != "a" || responses[0]["result"] == nil {
+		t.Errorf("first response = %v, want a result for id a", responses[0])
+	}
+	for _, r := range responses[1:] {
+		if r["error"] == nil {
+			t.Errorf("response = %v, want an error", r)
+		}
+	}
+
+	resp = mcpRequest(t, ts, http.MethodPost, id, `[{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}]`)
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("batch of notifications returned %d, want 202", resp.StatusCode)
+	}
+
+	resp = mcpRequest(t, ts, http.MethodPost, id, `[]`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("empty batch returned %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestHTTPBatchRejectedAfter20250618(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	resp := mcpRequest(t, ts, http.MethodPost, id, `[{"jsonrpc":"2.0","id":1,"method":"prompts/list"}]`)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("batch on protocol 2025-06-18 returned %d, want 400", resp.StatusCode)
+	}
+}
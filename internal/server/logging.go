@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+const clientLoggerName = "rancher-mcp"
+
+// logrusToMCPLevel maps logrus levels onto the syslog levels MCP uses
+var logrusToMCPLevel = map[logrus.Level]mcp.LoggingLevel{
+	logrus.TraceLevel: mcp.LoggingLevelDebug,
+	logrus.DebugLevel: mcp.LoggingLevelDebug,
+	logrus.InfoLevel:  mcp.LoggingLevelInfo,
+	logrus.WarnLevel:  mcp.LoggingLevelWarning,
+	logrus.ErrorLevel: mcp.LoggingLevelError,
+	logrus.FatalLevel: mcp.LoggingLevelCritical,
+	logrus.PanicLevel: mcp.LoggingLevelEmergency,
+}
+
+// MCPLoggingLevel converts a logrus level to its MCP equivalent
+func MCPLoggingLevel(level logrus.Level) mcp.LoggingLevel {
+	if mapped, ok := logrusToMCPLevel[level]; ok {
+		return mapped
+	}
+	return mcp.LoggingLevelInfo
+}
+
+// clientLogHook forwards logrus entries to MCP clients as
+// notifications/message. Entries logged with a request context go only to the
+// client that made the request.
+type clientLogHook struct {
+	mcpServer *mcp.Server
+}
+
+func (h *clientLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *clientLogHook) Fire(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var data interface{} = entry.Message
+	if len(entry.Data) > 0 {
+		fields := make(map[string]interface{}, len(entry.Data)+1)
+		for k, v := range entry.Data {
+			if err, ok := v.(error); ok {
+				v = err.Error()
+			}
+			fields[k] = v
+		}
+		fields["message"] = entry.Message
+		data = fields
+	}
+
+	h.mcpServer.LogMessage(ctx, MCPLoggingLevel(entry.Level), clientLoggerName, data)
+	return nil
+}
+
+// EnableClientLogging forwards entries from logger to connected MCP clients.
+// Clients receive entries at or above the level they select with
+// logging/setLevel, starting from defaultLevel; entries below the logger's
+// own level are never produced.
+func (s *Server) EnableClientLogging(logger *logrus.Logger, defaultLevel mcp.LoggingLevel) error {
+	if err := s.mcpServer.SetDefaultLogLevel(defaultLevel); err != nil {
+		return err
+	}
+	logger.AddHook(&clientLogHook{mcpServer: s.mcpServer})
+	return nil
+}
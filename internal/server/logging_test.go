@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// newLoggedSession returns a logger forwarding to an initialized session of
+// s, and the notifications the session receives
+func newLoggedSession(t *testing.T, s *Server, level logrus.Level) (*logrus.Logger, *mcp.Session, chan *mcp.JSONRPCNotification) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(level)
+	if err := s.EnableClientLogging(logger, MCPLoggingLevel(level)); err != nil {
+		t.Fatalf("EnableClientLogging failed: %v", err)
+	}
+
+	notifications := make(chan *mcp.JSONRPCNotification, 16)
+	sess := s.mcpServer.NewSession(func(n *mcp.JSONRPCNotification) error {
+		notifications <- n
+		return nil
+	})
+	t.Cleanup(func() { s.mcpServer.CloseSession(sess.ID()) })
+	resp := s.mcpServer.HandleRequest(mcp.WithSession(context.Background(), sess), &mcp.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "initialize",
+		Params:  map[string]interface{}{"protocolVersion": mcp.LatestProtocolVersion},
+	})
+	if resp.Error != nil {
+		t.Fatalf("initialize failed: %s", resp.Error.Message)
+	}
+	return logger, sess, notifications
+}
+
+func TestClientLogHook(t *testing.T) {
+	s := NewServerWithOptions(Options{})
+	logger, sess, notifications := newLoggedSession(t, s, logrus.InfoLevel)
+	ctx := mcp.WithSession(context.Background(), sess)
+
+	logger.WithContext(ctx).WithError(errors.New("refused")).Warn("request failed")
+	logger.WithContext(ctx).Debug("below the logger level")
+	// Entries without a request stay out of HTTP sessions
+	logger.Error("invalid API key")
+
+	close(notifications)
+	var got []*mcp.LoggingMessageNotification
+	for n := range notifications {
+		if n.Method == "notifications/message" {
+			got = append(got, n.Params.(*mcp.LoggingMessageNotification))
+		}
+	}
+	if len(got) != 1 {
+		t.Fatalf("session received %d messages, want 1", len(got))
+	}
+	if got[0].Level != mcp.LoggingLevelWarning || got[0].Logger != clientLoggerName {
+		t.Errorf("message = %+v, want a warning from %s", got[0], clientLoggerName)
+	}
+	data, _ := got[0].Data.(map[string]interface{})
+	if data["message"] != "request failed" || data["error"] != "refused" {
+		t.Errorf("data = %v, want the message and the error as a string", got[0].Data)
+	}
+}
+
+func TestMCPLoggingLevel(t *testing.T) {
+	tests := map[logrus.Level]mcp.LoggingLevel{
+		logrus.TraceLevel: mcp.LoggingLevelDebug,
+		logrus.InfoLevel:  mcp.LoggingLevelInfo,
+		logrus.WarnLevel:  mcp.LoggingLevelWarning,
+		logrus.PanicLevel: mcp.LoggingLevelEmergency,
+		logrus.Level(42):  mcp.LoggingLevelInfo,
+	}
+	for level, want := range tests {
+		if got := MCPLoggingLevel(level); got != want {
+			t.Errorf("MCPLoggingLevel(%v) = %s, want %s", level, got, want)
+		}
+	}
+}
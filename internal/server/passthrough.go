@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/auth"
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// callerClientIdleTimeout is how long an unused per-caller client is kept
+const callerClientIdleTimeout = 15 * time.Minute
+
+type callerClient struct {
+	client   *client.RancherClient
+	lastUsed time.Time
+}
+
+// callerClients caches one RancherClient per caller token. Keys are token
+// hashes so that tokens are not kept as map keys.
+type callerClients struct {
+	base *client.RancherClient
+
+	mu      sync.Mutex
+	clients map[string]*callerClient
+}
+
+func newCallerClients(base *client.RancherClient) *callerClients {
+	return &callerClients{
+		base:    base,
+		clients: make(map[string]*callerClient),
+	}
+}
+
+// get returns the client for token, creating it on first use
+func (cc *callerClients) get(token string) *client.RancherClient {
+	key := tokenHash(token)
+	now := time.Now()
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if entry, ok := cc.clients[key]; ok {
+		entry.lastUsed = now
+		return entry.client
+	}
+
+	for k, entry := range cc.clients {
+		if now.Sub(entry.lastUsed) > callerClientIdleTimeout {
+			delete(cc.clients, k)
+		}
+	}
+
+	c := cc.base.WithToken(token)
+	cc.clients[key] = &callerClient{client: c, lastUsed: now}
+	return c
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// rancherTokenHeader carries the caller's Rancher token when the
+// Authorization header is taken by MCP authentication
+const rancherTokenHeader = "X-Rancher-Token"
+
+// callerToken returns the Rancher token the caller supplied, if token
+// passthrough is enabled
+func (s *Server) callerToken(r *http.Request) string {
+	if s.callerClients == nil {
+		return ""
+	}
+	if s.authenticator != nil {
+		return strings.TrimSpace(r.Header.Get(rancherTokenHeader))
+	}
+	return auth.BearerToken(r)
+}
+
+// callerIdentity identifies the caller of r for session ownership: the
+// authenticated identity and the Rancher token it acts with. Without
+// authentication or token passthrough every caller shares one identity.
+func (s *Server) callerIdentity(r *http.Request) string {
+	var owner string
+	if id, ok := auth.FromContext(r.Context()); ok {
+		owner = id.Qualified(id.Subject)
+	}
+	if token := s.callerToken(r); token != "" {
+		owner += "|" + tokenHash(token)
+	}
+	return owner
+}
+
+// writeUnauthorized rejects a request that lacks the caller's Rancher token
+func (s *Server) writeUnauthorized(w http.ResponseWriter) {
+	if s.authenticator != nil {
+		http.Error(w, "A Rancher API token is required in the "+rancherTokenHeader+" header", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("WWW-Authenticate", `Bearer realm="rancher-mcp"`)
+	http.Error(w, "A Rancher API token is required in the Authorization header", http.StatusUnauthorized)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher-manager-mcp/internal/client"
+)
+
+// rancherRecorder is a fake Rancher API that records the token of every
+// request. Watches stay open until the client goes away.
+type rancherRecorder struct {
+	mu       sync.Mutex
+	requests []string // "token list|watch path"
+}
+
+func newRancherRecorder(t *testing.T) (*rancherRecorder, *httptest.Server) {
+	t.Helper()
+	rec := &rancherRecorder{}
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := "list"
+		if r.URL.Query().Get("watch") != "" {
+			kind = "watch"
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, token+" "+kind+" "+r.URL.Path)
+		rec.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if kind == "watch" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-done:
+			}
+			return
+		}
+		w.Write([]byte(`{"metadata":{"resourceVersion":"1"},"items":[]}`))
+	}))
+	t.Cleanup(ts.Close)
+	// Close waits for open watches, which would otherwise outlive the test
+	t.Cleanup(func() { close(done) })
+	return rec, ts
+}
+
+// waitFor waits until a request with the given token and kind was recorded
+func (rec *rancherRecorder) waitFor(t *testing.T, token, kind string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rec.mu.Lock()
+		for _, r := range rec.requests {
+			if strings.HasPrefix(r, token+" "+kind+" ") {
+				rec.mu.Unlock()
+				return
+			}
+		}
+		rec.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Rancher received no %s with token %s; requests: %v", kind, token, rec.requests)
+}
+
+func (rec *rancherRecorder) tokens() map[string]bool {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	tokens := make(map[string]bool)
+	for _, r := range rec.requests {
+		token, _, _ := strings.Cut(r, " ")
+		tokens[token] = true
+	}
+	return tokens
+}
+
+func TestCallerClients(t *testing.T) {
+	cc := newCallerClients(client.NewRancherClient("https://rancher.example.com", "", false))
+
+	a := cc.get("token-a")
+	if cc.get("token-a") != a {
+		t.Error("the client for a token was not reused")
+	}
+	if cc.get("token-b") == a {
+		t.Error("two tokens share a client")
+	}
+	for key := range cc.clients {
+		if strings.Contains(key, "token") {
+			t.Errorf("cache key %q holds the token", key)
+		}
+	}
+
+	// Creating a client evicts the ones idle for too long
+	cc.clients[tokenHash("token-a")].lastUsed = time.Now().Add(-2 * callerClientIdleTimeout)
+	cc.get("token-c")
+	if _, ok := cc.clients[tokenHash("token-a")]; ok {
+		t.Error("idle client was not evicted")
+	}
+	if _, ok := cc.clients[tokenHash("token-b")]; !ok {
+		t.Error("recently used client was evicted")
+	}
+}
+
+func TestTokenPassthroughRequiresToken(t *testing.T) {
+	_, rancher := newRancherRecorder(t)
+	_, ts := newTestHTTPServer(t, Options{RancherURL: rancher.URL, TokenPassthrough: true})
+
+	resp := mcpRequest(t, ts, http.MethodPost, "", initializeBody("2025-06-18"))
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("initialize without a token returned %d, want 401", resp.StatusCode)
+	}
+	if resp.Header.Get("WWW-Authenticate") == "" {
+		t.Error("401 has no WWW-Authenticate header")
+	}
+}
+
+func TestTokenPassthrough(t *testing.T) {
+	rec, rancher := newRancherRecorder(t)
+	_, ts := newTestHTTPServer(t, Options{RancherURL: rancher.URL, RancherToken: "server-token", TokenPassthrough: true})
+	alice := initializeSession(t, ts, "Authorization", "Bearer token-a")
+
+	call := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_clusters","arguments":{}}}`
+	resp := mcpRequest(t, ts, http.MethodPost, alice, call, "Authorization", "Bearer token-a")
+	if msg := decodeResponse(t, resp); msg["error"] != nil {
+		t.Fatalf("list_clusters failed: %v", msg["error"])
+	}
+	rec.waitFor(t, "token-a", "list")
+
+	// A session only serves the token that created it
+	resp = mcpRequest(t, ts, http.MethodPost, alice, call, "Authorization", "Bearer token-b")
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("request with another token returned %d, want 403", resp.StatusCode)
+	}
+	if rec.tokens()["token-b"] || rec.tokens()["server-token"] {
+		t.Errorf("Rancher received tokens %v, want only token-a", rec.tokens())
+	}
+}
+
+func TestTokenPassthroughSubscriptions(t *testing.T) {
+	rec, rancher := newRancherRecorder(t)
+	_, ts := newTestHTTPServer(t, Options{RancherURL: rancher.URL, TokenPassthrough: true})
+	subscribe := `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"rancher://clusters"}}`
+
+	for _, token := range []string{"token-a", "token-b"} {
+		id := initializeSession(t, ts, "Authorization", "Bearer "+token)
+		resp := mcpRequest(t, ts, http.MethodPost, id, subscribe, "Authorization", "Bearer "+token)
+		if msg := decodeResponse(t, resp); msg["error"] != nil {
+			t.Fatalf("subscribe with %s failed: %v", token, msg["error"])
+		}
+	}
+
+	// Each caller watches with its own token instead of sharing the watch
+	// of the first subscriber
+	rec.waitFor(t, "token-a", "watch")
+	rec.waitFor(t, "token-b", "watch")
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newReadyCluster serves a Rancher API in which every cluster is Ready
+func newReadyCluster(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"metadata":{"name":"c-1"},"status":{"conditions":[{"type":"Ready","status":"True"}]}}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// readEvents returns the data of every SSE event in the response
+func readEvents(t *testing.T, resp *http.Response) []map[string]interface{} {
+	t.Helper()
+	var events []map[string]interface{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var msg map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			t.Fatalf("invalid event %q: %v", data, err)
+		}
+		events = append(events, msg)
+	}
+	return events
+}
+
+func TestHTTPProgressUpgradesToStream(t *testing.T) {
+	rancher := newReadyCluster(t)
+	_, ts := newTestHTTPServer(t, Options{RancherURL: rancher.URL, RancherToken: "token"})
+	id := initializeSession(t, ts)
+
+	call := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"wait_for_cluster_ready","arguments":{"name":"c-1"},"_meta":{"progressToken":7}}}`
+	resp := mcpRequest(t, ts, http.MethodPost, id, call, "Accept", "application/json, "+eventStreamMimeType)
+	if resp.Header.Get("Content-Type") != eventStreamMimeType {
+		t.Fatalf("Content-Type = %s, want an event stream", resp.Header.Get("Content-Type"))
+	}
+
+	events := readEvents(t, resp)
+	if len(events) != 2 {
+		t.Fatalf("events = %v, want a progress notification and the response", events)
+	}
+	if events[0]["method"] != "notifications/progress" {
+		t.Errorf("first event = %v, want notifications/progress", events[0])
+	}
+	if params, _ := events[0]["params"].(map[string]interface{}); params["progressToken"] != float64(7) {
+		t.Errorf("progress params = %v, want progressToken 7", params)
+	}
+	if result, _ := events[1]["result"].(map[string]interface{}); events[1]["id"] != float64(2) || result == nil || result["isError"] == true {
+		t.Errorf("last event = %v, want the result of request 2", events[1])
+	}
+}
+
+func TestHTTPNoProgressStaysJSON(t *testing.T) {
+	rancher := newReadyCluster(t)
+	_, ts := newTestHTTPServer(t, Options{RancherURL: rancher.URL, RancherToken: "token"})
+	id := initializeSession(t, ts)
+
+	call := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"wait_for_cluster_ready","arguments":{"name":"c-1"}}}`
+	resp := mcpRequest(t, ts, http.MethodPost, id, call, "Accept", "application/json, "+eventStreamMimeType)
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Content-Type = %s, want application/json", resp.Header.Get("Content-Type"))
+	}
+	msg := decodeResponse(t, resp)
+	if result, _ := msg["result"].(map[string]interface{}); msg["id"] != float64(2) || result == nil || result["isError"] == true {
+		t.Errorf("response = %v, want the result of request 2", msg)
+	}
+}
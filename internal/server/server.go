@@ -7,10 +7,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sync"
 
+	"github.com/rancher/rancher-manager-mcp/internal/auth"
 	"github.com/rancher/rancher-manager-mcp/internal/client"
 	"github.com/rancher/rancher-manager-mcp/internal/mcp"
 	"github.com/rancher/rancher-manager-mcp/internal/server/handlers"
+	"github.com/sirupsen/logrus"
 )
 
 type Server struct {
@@ -18,73 +21,249 @@ type Server struct {
 	rancherToken string
 	client       *client.RancherClient
 	mcpServer    *mcp.Server
+
+	// callerClients is set when HTTP callers bring their own Rancher tokens
+	callerClients *callerClients
+	authenticator auth.Authenticator
+
+	httpSessions map[string]*httpSession
+	sessionsMu   sync.Mutex
+
+	// writeTools are the registered tools that change Rancher resources
+	writeTools []string
+}
+
+// Options configures a Server
+type Options struct {
+	RancherURL string
+	// RancherToken is the server's own token. With TokenPassthrough it may
+	// be empty; it is then only used by the stdio transport.
+	RancherToken       string
+	InsecureSkipVerify bool
+
+	// TokenPassthrough makes the HTTP transport act with the Rancher token
+	// each caller sends instead of RancherToken, so that Rancher RBAC and
+	// audit logs apply to the real user. The token is read from the
+	// Authorization header, or from X-Rancher-Token when Authenticator is set.
+	TokenPassthrough bool
+
+	// RetryPolicy, if set, replaces client.DefaultRetryPolicy for requests
+	// to Rancher
+	RetryPolicy *client.RetryPolicy
+
+	// Authenticator, if set, must accept every request to the HTTP MCP
+	// endpoint
+	Authenticator auth.Authenticator
+	// Policy, if set, limits the tools each authenticated caller may list
+	// and call
+	Policy *auth.Policy
+
+	// Tools limits which tools are registered at all
+	Tools ToolFilter
 }
 
 func NewServer(rancherURL, rancherToken string, insecureSkipVerify bool) *Server {
+	return NewServerWithOptions(Options{
+		RancherURL:         rancherURL,
+		RancherToken:       rancherToken,
+		InsecureSkipVerify: insecureSkipVerify,
+	})
+}
+
+// NewServerWithOptions creates a Server from opts
+func NewServerWithOptions(opts Options) *Server {
 	s := &Server{
-		rancherURL:   rancherURL,
-		rancherToken: rancherToken,
+		rancherURL:    opts.RancherURL,
+		rancherToken:  opts.RancherToken,
+		authenticator: opts.Authenticator,
+		httpSessions:  make(map[string]*httpSession),
 	}
 
 	// Initialize Rancher client
-	if rancherURL != "" && rancherToken != "" {
-		s.client = client.NewRancherClient(rancherURL, rancherToken, insecureSkipVerify)
+	if opts.RancherURL != "" && opts.RancherToken != "" {
+		s.client = client.NewRancherClient(opts.RancherURL, opts.RancherToken, opts.InsecureSkipVerify)
+		if opts.RetryPolicy != nil {
+			s.client.SetRetryPolicy(*opts.RetryPolicy)
+		}
+	}
+	if opts.TokenPassthrough && opts.RancherURL != "" {
+		base := s.client
+		if base == nil {
+			base = client.NewRancherClient(opts.RancherURL, "", opts.InsecureSkipVerify)
+			if opts.RetryPolicy != nil {
+				base.SetRetryPolicy(*opts.RetryPolicy)
+			}
+		}
+		s.callerClients = newCallerClients(base)
 	}
 
 	// Initialize MCP server
 	s.mcpServer = mcp.NewServer("rancher-manager-mcp", "1.0.0")
-	s.registerTools()
+	s.mcpServer.SetErrorFormatter(handlers.FormatError)
+	s.registerTools(opts.Tools)
 	s.registerResources()
+	s.registerPrompts()
+	s.registerCompletions()
+
+	if opts.Policy != nil {
+		policy := opts.Policy
+		s.mcpServer.SetToolAuthorizer(func(ctx context.Context, tool string) bool {
+			id, ok := auth.FromContext(ctx)
+			if !ok {
+				// Only authenticated HTTP requests carry an identity; the
+				// stdio transport is trusted like the local user running it
+				return true
+			}
+			return policy.Allowed(id, tool)
+		})
+	}
 
 	return s
 }
 
+// SetToolsPageSize sets how many tools each tools/list page returns
+func (s *Server) SetToolsPageSize(size int) {
+	s.mcpServer.SetToolsPageSize(size)
+}
+
 func (s *Server) ServeStdio(ctx context.Context) error {
 	return s.mcpServer.Serve(ctx, os.Stdin, os.Stdout)
 }
 
 func (s *Server) HTTPHandler() http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/mcp", s.handleMCPRequest)
+	var mcpHandler http.Handler = http.HandlerFunc(s.handleMCPRequest)
+	if s.authenticator != nil {
+		mcpHandler = auth.Middleware(s.authenticator, mcpHandler)
+	}
+	mux.Handle("/mcp", mcpHandler)
 	mux.HandleFunc("/health", s.handleHealth)
 	return mux
 }
 
 func (s *Server) handleMCPRequest(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
+	switch r.Method {
+	case http.MethodPost:
+		s.handleMCPPost(w, r)
+	case http.MethodGet:
+		s.handleMCPStream(w, r)
+	case http.MethodDelete:
+		s.handleMCPDelete(w, r)
+	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
 	}
+}
 
+func (s *Server) handleMCPPost(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read request: %v", err), http.StatusBadRequest)
+		writeJSONRPCError(w, http.StatusBadRequest, nil, &mcp.JSONRPCError{
+			Code:    -32700,
+			Message: fmt.Sprintf("Parse error: failed to read request: %v", err),
+		})
 		return
 	}
 
-	var mcpRequest mcp.JSONRPCRequest
-	if err := json.Unmarshal(body, &mcpRequest); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
+	messages, isBatch, parseErr := parseJSONRPCBody(body)
+	if parseErr != nil {
+		writeJSONRPCError(w, http.StatusBadRequest, nil, parseErr)
 		return
 	}
 
-	// Handle MCP request
-	response := s.mcpServer.HandleRequest(r.Context(), &mcpRequest)
+	// With token passthrough every request must carry the caller's token
+	callerToken := s.callerToken(r)
+	if s.callerClients != nil && callerToken == "" {
+		s.writeUnauthorized(w)
+		return
+	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	// initialize starts a new session; everything else must belong to one
+	var hs *httpSession
+	initialize := !isBatch && messages[0].invalid == nil && messages[0].Method == "initialize"
+	if initialize && messages[0].ID == nil {
+		writeJSONRPCError(w, http.StatusBadRequest, nil, &mcp.JSONRPCError{
+			Code:    -32600,
+			Message: "Invalid Request: initialize must be a request with an id",
+		})
+		return
+	}
+	if initialize {
+		hs = s.newHTTPSession(s.callerIdentity(r))
+		w.Header().Set(sessionHeader, hs.session.ID())
+	} else {
+		var status int
+		var message string
+		hs, status, message = s.lookupHTTPSession(r)
+		if hs == nil {
+			writeJSONRPCError(w, status, nil, &mcp.JSONRPCError{
+				Code:    -32600,
+				Message: message,
+			})
+			return
+		}
+	}
+
+	// JSON-RPC batching was removed from the protocol in 2025-06-18
+	if isBatch && mcp.ProtocolVersionAtLeast(hs.session.ProtocolVersion(), mcp.ProtocolVersion20250618) {
+		writeJSONRPCError(w, http.StatusBadRequest, nil, &mcp.JSONRPCError{
+			Code:    -32600,
+			Message: fmt.Sprintf("Invalid Request: batches are not supported in protocol version %s", hs.session.ProtocolVersion()),
+		})
+		return
+	}
+
+	// Subscriptions of sessions with the same owner share Rancher watches,
+	// which run with the credentials of that owner
+	ctx := mcp.WithCaller(mcp.WithSession(r.Context(), hs.session), hs.owner)
+	if callerToken != "" {
+		ctx = client.NewContext(ctx, s.callerClients.get(callerToken))
+	}
+	stream := &sseResponse{w: w}
+	if acceptsEventStream(r) {
+		ctx = mcp.WithRequestNotifier(ctx, stream.notify)
+	}
+
+	if isBatch {
+		responses := s.dispatchBatch(ctx, messages)
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		stream.finish(responses)
+		return
+	}
+
+	var response *mcp.JSONRPCResponse
+	if initialize {
+		response = s.mcpServer.HandleRequest(ctx, &messages[0].JSONRPCRequest)
+		// A cancelled initialize has no response and leaves no session
+		if response == nil || response.Error != nil {
+			s.closeHTTPSession(hs.session.ID())
+			w.Header().Del(sessionHeader)
+		}
+	} else {
+		response = s.dispatchMessage(ctx, messages[0])
+	}
+
+	// Notifications and responses are acknowledged without a body
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	stream.finish(response)
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	status := map[string]interface{}{
 		"status":             "ok",
-		"rancher_configured": s.client != nil,
+		"rancher_configured": s.client != nil || s.callerClients != nil,
 	}
 	json.NewEncoder(w).Encode(status)
 }
 
-func (s *Server) registerTools() {
+func (s *Server) registerTools(filter ToolFilter) {
 	// Register tools from handler modules
 	handlers.RegisterClusterTools(s.mcpServer, s.client)
 	handlers.RegisterUserTools(s.mcpServer, s.client)
@@ -105,7 +284,58 @@ func (s *Server) registerTools() {
 	handlers.RegisterRoleStatusTools(s.mcpServer, s.client)
 	handlers.RegisterBindingStatusTools(s.mcpServer, s.client)
 	handlers.RegisterAuditPolicyStatusTools(s.mcpServer, s.client)
+	handlers.RegisterClusterWaitTools(s.mcpServer, s.client)
+
+	readTools := s.mcpServer.ToolNames()
+	if filter.ReadOnly {
+		logrus.Info("Read-only mode: create, update and delete tools are not registered")
+	} else {
+		s.registerWriteTools()
+	}
+
+	// Annotations and completions are derived from the registered set, so
+	// filter it first
+	filter.apply(s.mcpServer)
+	s.writeTools = newToolNames(readTools, s.mcpServer.ToolNames())
+	logrus.Infof("Registered %d tools", len(s.mcpServer.ToolNames()))
 
+	handlers.ApplyToolMetadata(s.mcpServer)
+}
+
+// SetWriteToolsEnabled hides or restores the create, update, patch, apply and
+// delete tools while the server is running, for example during a change
+// freeze. Connected clients are told to refresh their tool list. It has no
+// effect on a server created in read-only mode, which never registers them.
+func (s *Server) SetWriteToolsEnabled(enabled bool) {
+	for _, name := range s.writeTools {
+		if err := s.mcpServer.SetToolEnabled(name, enabled); err != nil {
+			logrus.Warnf("Failed to toggle tool %s: %v", name, err)
+		}
+	}
+	if len(s.writeTools) > 0 {
+		logrus.Infof("Write tools enabled: %t", enabled)
+	}
+}
+
+// newToolNames returns the names in after that are not in before. Both are
+// sorted, as returned by ToolNames.
+func newToolNames(before, after []string) []string {
+	var added []string
+	i := 0
+	for _, name := range after {
+		for i < len(before) && before[i] < name {
+			i++
+		}
+		if i < len(before) && before[i] == name {
+			continue
+		}
+		added = append(added, name)
+	}
+	return added
+}
+
+// registerWriteTools registers the tools that change Rancher resources
+func (s *Server) registerWriteTools() {
 	// Register create and update tools
 	handlers.RegisterClusterCreateUpdateTools(s.mcpServer, s.client)
 	handlers.RegisterUserCreateUpdateTools(s.mcpServer, s.client)
@@ -118,6 +348,7 @@ func (s *Server) registerTools() {
 	handlers.RegisterRoleTemplateCreateUpdateTools(s.mcpServer, s.client)
 	handlers.RegisterClusterRoleTemplateBindingCreateUpdateTools(s.mcpServer, s.client)
 	handlers.RegisterProjectRoleTemplateBindingCreateUpdateTools(s.mcpServer, s.client)
+	handlers.RegisterApplyTools(s.mcpServer, s.client)
 
 	// Register delete tools
 	handlers.RegisterClusterDeleteTools(s.mcpServer, s.client)
@@ -134,5 +365,15 @@ func (s *Server) registerTools() {
 }
 
 func (s *Server) registerResources() {
-	// Register resources if needed
+	if err := handlers.RegisterResources(s.mcpServer, s.client); err != nil {
+		logrus.Errorf("Failed to register resources: %v", err)
+	}
+}
+
+func (s *Server) registerPrompts() {
+	handlers.RegisterPrompts(s.mcpServer)
+}
+
+func (s *Server) registerCompletions() {
+	handlers.RegisterCompletions(s.mcpServer, s.client)
 }
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rancher/rancher-manager-mcp/internal/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// ToolFilter limits the tools a Server registers
+type ToolFilter struct {
+	// ReadOnly registers only the list, get, status and wait tools
+	ReadOnly bool
+	// Enable, if not empty, keeps only the tools matching one of its patterns
+	Enable []string
+	// Disable removes the tools matching any of its patterns, even if they
+	// also match Enable
+	Disable []string
+}
+
+// ParseToolPatterns splits a comma-separated list of tool name globs, such as
+// "delete_*,*_token*", and checks that each one is valid
+func ParseToolPatterns(list string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q", pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// apply unregisters the tools that do not pass the enable and disable lists
+func (f ToolFilter) apply(mcpServer *mcp.Server) {
+	if len(f.Enable) == 0 && len(f.Disable) == 0 {
+		return
+	}
+
+	names := mcpServer.ToolNames()
+	for _, pattern := range append(append([]string(nil), f.Enable...), f.Disable...) {
+		if !matchesAny([]string{pattern}, names) {
+			logrus.Warnf("Tool pattern %q matches no registered tools", pattern)
+		}
+	}
+
+	for _, name := range names {
+		if len(f.Enable) > 0 && !matchesAny(f.Enable, []string{name}) {
+			mcpServer.UnregisterTool(name)
+			continue
+		}
+		if matchesAny(f.Disable, []string{name}) {
+			mcpServer.UnregisterTool(name)
+		}
+	}
+}
+
+// matchesAny reports whether any of patterns matches any of names
+func matchesAny(patterns, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestToolFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  ToolFilter
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "all tools",
+			present: []string{"list_clusters", "create_project", "delete_cluster", "apply_resource"},
+		},
+		{
+			name:    "read-only",
+			filter:  ToolFilter{ReadOnly: true},
+			present: []string{"list_clusters", "get_user", "get_cluster_status", "wait_for_cluster_ready"},
+			absent:  []string{"create_project", "update_user", "patch_cluster", "delete_cluster", "apply_resource"},
+		},
+		{
+			name:    "disable",
+			filter:  ToolFilter{Disable: []string{"delete_*", "*_token*"}},
+			present: []string{"list_clusters", "create_project"},
+			absent:  []string{"delete_cluster", "list_tokens", "create_token"},
+		},
+		{
+			name:    "enable and disable",
+			filter:  ToolFilter{Enable: []string{"*_cluster*"}, Disable: []string{"delete_*"}},
+			present: []string{"list_clusters", "create_cluster", "get_cluster_status"},
+			absent:  []string{"list_users", "delete_cluster"},
+		},
+		{
+			name:    "read-only wins over enable",
+			filter:  ToolFilter{ReadOnly: true, Enable: []string{"*_global_role_binding"}},
+			present: []string{"get_global_role_binding"},
+			absent:  []string{"create_global_role_binding", "list_clusters"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewServerWithOptions(Options{Tools: tc.filter})
+			registered := make(map[string]bool)
+			for _, name := range s.mcpServer.ToolNames() {
+				registered[name] = true
+			}
+			for _, name := range tc.present {
+				if !registered[name] {
+					t.Errorf("%s is not registered", name)
+				}
+			}
+			for _, name := range tc.absent {
+				if registered[name] {
+					t.Errorf("%s is registered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseToolPatterns(t *testing.T) {
+	patterns, err := ParseToolPatterns(" delete_*, *_token*,,")
+	if err != nil {
+		t.Fatalf("ParseToolPatterns failed: %v", err)
+	}
+	if strings.Join(patterns, "|") != "delete_*|*_token*" {
+		t.Errorf("patterns = %q", patterns)
+	}
+	if _, err := ParseToolPatterns("list_["); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+// listTools returns the tools the session sees in tools/list, by name
+func listTools(t *testing.T, ts *httptest.Server, sessionID string) map[string]map[string]interface{} {
+	t.Helper()
+	resp := mcpRequest(t, ts, http.MethodPost, sessionID, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	var msg struct {
+		Result struct {
+			Tools []map[string]interface{} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := jsonDecode(resp, &msg); err != nil {
+		t.Fatalf("failed to decode tools/list: %v", err)
+	}
+	listed := make(map[string]map[string]interface{})
+	for _, tool := range msg.Result.Tools {
+		listed[tool["name"].(string)] = tool
+	}
+	return listed
+}
+
+func TestEveryToolHasACategory(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	for name, tool := range listTools(t, ts, id) {
+		meta, _ := tool["_meta"].(map[string]interface{})
+		if categories, _ := meta["categories"].([]interface{}); len(categories) == 0 {
+			t.Errorf("%s has no category", name)
+		}
+	}
+}
+
+func TestToolAnnotations(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	// Expected hints by verb, spelled out here rather than taken from the
+	// handlers so that a new verb or a changed hint has to be decided on
+	type hints struct{ readOnly, destructive, idempotent bool }
+	want := map[string]hints{
+		"list":   {readOnly: true},
+		"get":    {readOnly: true},
+		"wait":   {readOnly: true},
+		"create": {},
+		"update": {destructive: true, idempotent: true},
+		"patch":  {destructive: true},
+		"apply":  {destructive: true, idempotent: true},
+		"delete": {destructive: true, idempotent: true},
+	}
+	hint := func(annotations map[string]interface{}, name string) (bool, bool) {
+		value, ok := annotations[name].(bool)
+		return value, ok
+	}
+
+	for name, tool := range listTools(t, ts, id) {
+		verb, _, _ := strings.Cut(name, "_")
+		expected, ok := want[verb]
+		if !ok {
+			t.Errorf("%s has a verb with no expected annotations", name)
+			continue
+		}
+		annotations, _ := tool["annotations"].(map[string]interface{})
+		if readOnly, ok := hint(annotations, "readOnlyHint"); !ok || readOnly != expected.readOnly {
+			t.Errorf("%s readOnlyHint = %v, want %v", name, annotations["readOnlyHint"], expected.readOnly)
+		}
+		if expected.readOnly {
+			continue
+		}
+		if destructive, ok := hint(annotations, "destructiveHint"); !ok || destructive != expected.destructive {
+			t.Errorf("%s destructiveHint = %v, want %v", name, annotations["destructiveHint"], expected.destructive)
+		}
+		if idempotent, ok := hint(annotations, "idempotentHint"); !ok || idempotent != expected.idempotent {
+			t.Errorf("%s idempotentHint = %v, want %v", name, annotations["idempotentHint"], expected.idempotent)
+		}
+	}
+}
+
+func TestReadToolsTakeOutputOptions(t *testing.T) {
+	_, ts := newTestHTTPServer(t, Options{})
+	id := initializeSession(t, ts)
+
+	for name, tool := range listTools(t, ts, id) {
+		if !strings.HasPrefix(name, "get_") && !strings.HasPrefix(name, "list_") {
+			continue
+		}
+		schema, _ := tool["inputSchema"].(map[string]interface{})
+		properties, _ := schema["properties"].(map[string]interface{})
+		if properties["fields"] == nil || properties["output"] == nil {
+			t.Errorf("%s does not take fields and output", name)
+		}
+	}
+}
+
+func TestSetWriteToolsEnabled(t *testing.T) {
+	s, ts := newTestHTTPServer(t, Options{Tools: ToolFilter{Disable: []string{"delete_*"}}})
+	id := initializeSession(t, ts)
+
+	s.SetWriteToolsEnabled(false)
+	listed := listTools(t, ts, id)
+	if listed["list_clusters"] == nil || listed["create_project"] != nil || listed["apply_resource"] != nil {
+		t.Error("tools/list during a freeze should hold only read tools")
+	}
+	resp := mcpRequest(t, ts, http.MethodPost, id, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"create_project","arguments":{}}}`)
+	if msg := decodeResponse(t, resp); msg["error"] == nil {
+		t.Errorf("tools/call during a freeze = %v, want an error", msg)
+	}
+
+	s.SetWriteToolsEnabled(true)
+	listed = listTools(t, ts, id)
+	if listed["create_project"] == nil || listed["apply_resource"] == nil {
+		t.Error("tools/list after the freeze should hold the write tools again")
+	}
+	if listed["delete_cluster"] != nil {
+		t.Error("delete_cluster came back although --disable-tools removed it")
+	}
+}